@@ -1,7 +1,9 @@
 package carry
 
 import (
+	ut "github.com/go-playground/universal-translator"
 	"github.com/go-playground/validator/v10"
+	"github.com/things-go/dyn/errorx"
 	"github.com/things-go/dyn/transport"
 	"github.com/things-go/encoding"
 )
@@ -11,6 +13,9 @@ type Applier interface {
 	setValidation(*validator.Validate)
 	setTransformError(transport.TransformError)
 	setTransformBody(transport.TransformBody)
+	setCatalog(*errorx.Catalog)
+	setTranslator(ut.Translator)
+	setConverter(errorx.Converter)
 }
 
 type Option func(Applier)
@@ -36,3 +41,25 @@ func WithTransformBody(t transport.TransformBody) Option {
 		cy.setTransformBody(t)
 	}
 }
+
+// WithCatalog localize the error message with the catalog when render the error.
+func WithCatalog(c *errorx.Catalog) Option {
+	return func(cy Applier) {
+		cy.setCatalog(c)
+	}
+}
+
+// WithTranslator translate the message of the field violations when validate failed.
+func WithTranslator(t ut.Translator) Option {
+	return func(cy Applier) {
+		cy.setTranslator(t)
+	}
+}
+
+// WithConverter convert the status code of the error with the converter when render the error
+// via `ErrorTransformer` or `ProblemTransformer`, default errorx.DefaultConverter.
+func WithConverter(c errorx.Converter) Option {
+	return func(cy Applier) {
+		cy.setConverter(c)
+	}
+}
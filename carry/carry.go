@@ -1,62 +1,29 @@
 package carry
 
 import (
-	"context"
-	"net/http"
-
 	"github.com/gin-gonic/gin"
-	"github.com/go-playground/validator/v10"
-	"github.com/things-go/encoding"
 
-	"github.com/things-go/dyn/transport"
 	transportHttp "github.com/things-go/dyn/transport/http"
 )
 
 var _ transportHttp.Carrier = (*Carry)(nil)
+var _ transportHttp.EventCarrier = (*Carry)(nil)
 var _ Applier = (*Carry)(nil)
 
+// Carry is the gin adapter over the `CarryStd`, the uri is bound from the gin params.
 type Carry struct {
-	encoding       *encoding.Encoding
-	validation     *validator.Validate
-	transformError transport.TransformError
-	transformBody  transport.TransformBody
+	*CarryStd
 }
 
 func NewCarry(opts ...Option) *Carry {
-	cy := &Carry{
-		encoding: encoding.New(),
-		validation: func() *validator.Validate {
-			v := validator.New()
-			v.SetTagName("binding")
-			return v
-		}(),
-	}
-	for _, opt := range opts {
-		opt(cy)
-	}
-	return cy
-}
-
-func (cy *Carry) setEncoding(e *encoding.Encoding) {
-	cy.encoding = e
-}
-func (cy *Carry) setValidation(v *validator.Validate) {
-	cy.validation = v
-}
-
-func (cy *Carry) setTransformError(e transport.TransformError) {
-	cy.transformError = e
-}
-
-func (cy *Carry) setTransformBody(e transport.TransformBody) {
-	cy.transformBody = e
+	return &Carry{NewCarryStd(opts...)}
 }
 
 func (cy *Carry) Bind(c *gin.Context, v any) error {
-	return cy.encoding.Bind(c.Request, v)
+	return cy.CarryStd.Bind(c.Request, v)
 }
 func (cy *Carry) BindQuery(c *gin.Context, v any) error {
-	return cy.encoding.BindQuery(c.Request, v)
+	return cy.CarryStd.BindQuery(c.Request, v)
 }
 func (cy *Carry) BindUri(c *gin.Context, v any) error {
 	return cy.encoding.BindUri(transportHttp.UrlValues(c.Params), v)
@@ -118,45 +85,16 @@ func (cy *Carry) ShouldBindQueryBodyUri(c *gin.Context, v any) error {
 	}
 	return cy.Validate(c.Request.Context(), v)
 }
-func (cy *Carry) Error(c *gin.Context, err error) {
-	var obj any
-	var statusCode = http.StatusInternalServerError
 
-	if cy.transformError != nil {
-		statusCode, obj = cy.transformError.TransformError(c.Request.Context(), err)
-	} else {
-		obj = err.Error()
-	}
-	c.Writer.WriteHeader(statusCode)
-	if err := cy.encoding.Render(c.Writer, c.Request, obj); err != nil {
-		c.String(http.StatusInternalServerError, "Render failed cause by %v", err)
-	}
+func (cy *Carry) Error(c *gin.Context, err error) {
+	cy.CarryStd.Error(c.Writer, c.Request, err)
 }
 func (cy *Carry) Render(c *gin.Context, v any) {
-	if cy.transformBody != nil {
-		v = cy.transformBody.TransformBody(c.Request.Context(), v)
-	}
-	c.Writer.WriteHeader(http.StatusOK)
-	err := cy.encoding.Render(c.Writer, c.Request, v)
-	if err != nil {
-		c.String(http.StatusInternalServerError, "Render failed cause by %v", err)
-	}
-}
-func (cy *Carry) Validator() *validator.Validate {
-	return cy.validation
-}
-func (cy *Carry) Validate(ctx context.Context, v any) error {
-	return cy.validation.StructCtx(ctx, v)
-}
-func (cy *Carry) StructCtx(ctx context.Context, v any) error {
-	return cy.validation.StructCtx(ctx, v)
-}
-func (cy *Carry) Struct(v any) error {
-	return cy.validation.Struct(v)
+	cy.CarryStd.Render(c.Writer, c.Request, v)
 }
-func (cy *Carry) VarCtx(ctx context.Context, v any, tag string) error {
-	return cy.validation.VarCtx(ctx, v, tag)
+func (cy *Carry) EncodeEvent(c *gin.Context, v any) ([]byte, error) {
+	return cy.CarryStd.EncodeEvent(c.Request, v)
 }
-func (cy *Carry) Var(v any, tag string) error {
-	return cy.validation.Var(v, tag)
+func (cy *Carry) EncodeErrorEvent(c *gin.Context, err error) ([]byte, error) {
+	return cy.CarryStd.EncodeErrorEvent(c.Request, err)
 }
@@ -2,32 +2,51 @@ package carry
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
+	"sync"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	ut "github.com/go-playground/universal-translator"
 	"github.com/go-playground/validator/v10"
 	"github.com/things-go/encoding"
 
+	"github.com/things-go/dyn/errorx"
 	"github.com/things-go/dyn/transport"
 	transportHttp "github.com/things-go/dyn/transport/http"
 )
 
 var _ transportHttp.Carrier = (*CarryGin)(nil)
+var _ transportHttp.EventCarrier = (*CarryGin)(nil)
 var _ Applier = (*CarryGin)(nil)
 
 type CarryGin struct {
 	validation     *validator.Validate
 	transformError transport.TransformError
 	transformBody  transport.TransformBody
+	catalog        *errorx.Catalog
+	translator     ut.Translator
+	converter      errorx.Converter
 }
 
+// registerGinFieldNameOnce register the field name func on the gin validator only once.
+var registerGinFieldNameOnce sync.Once
+
+// registerGinFieldName registers the json/proto field name func on the gin validator,
+// so the field violations of the gin binding are named as same as `Validate`.
+func registerGinFieldName() {
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(fieldName)
+	}
+}
+
+// NewCarryGin new carry with the gin binding, the field name func of the json/proto names
+// is registered on the gin validator too.
 func NewCarryGin(opts ...Option) *CarryGin {
+	registerGinFieldNameOnce.Do(registerGinFieldName)
 	cy := &CarryGin{
-		validation: func() *validator.Validate {
-			v := validator.New()
-			v.SetTagName("binding")
-			return v
-		}(),
+		validation: newValidation(),
 	}
 	for _, opt := range opts {
 		opt(cy)
@@ -48,14 +67,27 @@ func (cy *CarryGin) setTransformBody(e transport.TransformBody) {
 	cy.transformBody = e
 }
 
-func (*CarryGin) Bind(c *gin.Context, v any) error {
-	return c.ShouldBind(v)
+func (cy *CarryGin) setCatalog(c *errorx.Catalog) {
+	cy.catalog = c
+}
+
+func (cy *CarryGin) setTranslator(t ut.Translator) {
+	cy.translator = t
+}
+func (cy *CarryGin) setConverter(c errorx.Converter) {
+	cy.converter = c
+}
+
+// Bind bind with the gin binding, the `validator.ValidationErrors` of the gin validator
+// are converted via `ValidationError` too, unless gin validation is disabled.
+func (cy *CarryGin) Bind(c *gin.Context, v any) error {
+	return ValidationError(c.ShouldBind(v), cy.translator)
 }
-func (*CarryGin) BindQuery(c *gin.Context, v any) error {
-	return c.ShouldBindQuery(v)
+func (cy *CarryGin) BindQuery(c *gin.Context, v any) error {
+	return ValidationError(c.ShouldBindQuery(v), cy.translator)
 }
-func (*CarryGin) BindUri(c *gin.Context, v any) error {
-	return c.ShouldBindUri(v)
+func (cy *CarryGin) BindUri(c *gin.Context, v any) error {
+	return ValidationError(c.ShouldBindUri(v), cy.translator)
 }
 func (cy *CarryGin) ShouldBind(c *gin.Context, v any) error {
 	if err := cy.Bind(c, v); err != nil {
@@ -116,13 +148,10 @@ func (cy *CarryGin) ShouldBindQueryBodyUri(c *gin.Context, v any) error {
 }
 
 func (cy *CarryGin) Error(c *gin.Context, err error) {
-	var obj any
-	var statusCode = http.StatusInternalServerError
-
-	if cy.transformError != nil {
-		statusCode, obj = cy.transformError.TransformError(c.Request.Context(), err)
-	} else {
-		obj = err.Error()
+	statusCode, obj := errorBody(c.Request.Context(), err, cy.catalog, cy.transformError, cy.converter)
+	setRetryAfter(c.Writer.Header(), err)
+	if _, ok := obj.(*Problem); ok {
+		c.Header("Content-Type", MIMEProblemJSON+"; charset=utf-8")
 	}
 	c.AbortWithStatusJSON(statusCode, obj)
 }
@@ -132,11 +161,25 @@ func (cy *CarryGin) Render(c *gin.Context, v any) {
 	}
 	c.JSON(http.StatusOK, v)
 }
+
+// EncodeEvent encode the server-sent event message with json.
+func (cy *CarryGin) EncodeEvent(c *gin.Context, v any) ([]byte, error) {
+	if cy.transformBody != nil {
+		v = cy.transformBody.TransformBody(c.Request.Context(), v)
+	}
+	return json.Marshal(v)
+}
+
+// EncodeErrorEvent encode the server-sent event error with json, the body is same as `Error` renders.
+func (cy *CarryGin) EncodeErrorEvent(c *gin.Context, err error) ([]byte, error) {
+	_, obj := errorBody(c.Request.Context(), err, cy.catalog, cy.transformError, cy.converter)
+	return json.Marshal(obj)
+}
 func (cy *CarryGin) Validator() *validator.Validate {
 	return cy.validation
 }
 func (cy *CarryGin) Validate(ctx context.Context, v any) error {
-	return cy.validation.StructCtx(ctx, v)
+	return ValidationError(cy.validation.StructCtx(ctx, v), cy.translator)
 }
 func (cy *CarryGin) StructCtx(ctx context.Context, v any) error {
 	return cy.validation.StructCtx(ctx, v)
@@ -0,0 +1,42 @@
+package carry_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/things-go/dyn/carry"
+	"github.com/things-go/dyn/errorx"
+)
+
+func Test_CarryGin_BindValidation(t *testing.T) {
+	type helloRequest struct {
+		Name string `json:"name" form:"name" binding:"required"`
+	}
+	cy := carry.NewCarryGin()
+	for _, tt := range []struct {
+		name string
+		bind func(c *gin.Context, v any) error
+		req  *http.Request
+	}{
+		{"body", cy.Bind, httptest.NewRequest(http.MethodPost, "/v1/hello", strings.NewReader(`{}`))},
+		{"query", cy.BindQuery, httptest.NewRequest(http.MethodGet, "/v1/hello", nil)},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = tt.req
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			err := tt.bind(c, &helloRequest{})
+			require.True(t, errorx.EqualCode(err, http.StatusBadRequest))
+			vs := errorx.Parse(err).FieldViolations()
+			require.Len(t, vs, 1)
+			require.Equal(t, "name", vs[0].Field)
+			require.Equal(t, "required", vs[0].Tag)
+		})
+	}
+}
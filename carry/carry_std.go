@@ -0,0 +1,233 @@
+package carry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"github.com/things-go/encoding"
+
+	"github.com/things-go/dyn/errorx"
+	"github.com/things-go/dyn/transport"
+	"github.com/things-go/dyn/transport/nethttp"
+)
+
+var _ nethttp.Carrier = (*CarryStd)(nil)
+var _ Applier = (*CarryStd)(nil)
+
+// CarryStd is the framework-neutral carrier based on `http.ResponseWriter` and `*http.Request`,
+// the uri is bound from the `http.ServeMux` path values, see `nethttp.PathValues`.
+type CarryStd struct {
+	encoding       *encoding.Encoding
+	validation     *validator.Validate
+	transformError transport.TransformError
+	transformBody  transport.TransformBody
+	catalog        *errorx.Catalog
+	translator     ut.Translator
+	converter      errorx.Converter
+}
+
+func NewCarryStd(opts ...Option) *CarryStd {
+	cy := &CarryStd{
+		encoding:   encoding.New(),
+		validation: newValidation(),
+	}
+	for _, opt := range opts {
+		opt(cy)
+	}
+	return cy
+}
+
+func (cy *CarryStd) setEncoding(e *encoding.Encoding) {
+	cy.encoding = e
+}
+func (cy *CarryStd) setValidation(v *validator.Validate) {
+	cy.validation = v
+}
+
+func (cy *CarryStd) setTransformError(e transport.TransformError) {
+	cy.transformError = e
+}
+
+func (cy *CarryStd) setTransformBody(e transport.TransformBody) {
+	cy.transformBody = e
+}
+
+func (cy *CarryStd) setCatalog(c *errorx.Catalog) {
+	cy.catalog = c
+}
+
+func (cy *CarryStd) setTranslator(t ut.Translator) {
+	cy.translator = t
+}
+func (cy *CarryStd) setConverter(c errorx.Converter) {
+	cy.converter = c
+}
+
+func (cy *CarryStd) Bind(r *http.Request, v any) error {
+	return cy.encoding.Bind(r, v)
+}
+func (cy *CarryStd) BindQuery(r *http.Request, v any) error {
+	return cy.encoding.BindQuery(r, v)
+}
+func (cy *CarryStd) BindUri(r *http.Request, v any) error {
+	return cy.encoding.BindUri(nethttp.PathValues(r), v)
+}
+func (cy *CarryStd) ShouldBind(r *http.Request, v any) error {
+	if err := cy.Bind(r, v); err != nil {
+		return err
+	}
+	return cy.Validate(r.Context(), v)
+}
+func (cy *CarryStd) ShouldBindQuery(r *http.Request, v any) error {
+	if err := cy.BindQuery(r, v); err != nil {
+		return err
+	}
+	return cy.Validate(r.Context(), v)
+}
+func (cy *CarryStd) ShouldBindUri(r *http.Request, v any) error {
+	if err := cy.BindUri(r, v); err != nil {
+		return err
+	}
+	return cy.Validate(r.Context(), v)
+}
+func (cy *CarryStd) ShouldBindBodyUri(r *http.Request, v any) error {
+	if err := cy.Bind(r, v); err != nil {
+		return err
+	}
+	if err := cy.BindUri(r, v); err != nil {
+		return err
+	}
+	return cy.Validate(r.Context(), v)
+}
+func (cy *CarryStd) ShouldBindQueryUri(r *http.Request, v any) error {
+	if err := cy.BindQuery(r, v); err != nil {
+		return err
+	}
+	if err := cy.BindUri(r, v); err != nil {
+		return err
+	}
+	return cy.Validate(r.Context(), v)
+}
+func (cy *CarryStd) ShouldBindQueryBody(r *http.Request, v any) error {
+	if err := cy.BindQuery(r, v); err != nil {
+		return err
+	}
+	if err := cy.Bind(r, v); err != nil {
+		return err
+	}
+	return cy.Validate(r.Context(), v)
+}
+func (cy *CarryStd) ShouldBindQueryBodyUri(r *http.Request, v any) error {
+	if err := cy.BindQuery(r, v); err != nil {
+		return err
+	}
+	if err := cy.Bind(r, v); err != nil {
+		return err
+	}
+	if err := cy.BindUri(r, v); err != nil {
+		return err
+	}
+	return cy.Validate(r.Context(), v)
+}
+
+func (cy *CarryStd) Error(w http.ResponseWriter, r *http.Request, err error) {
+	statusCode, obj := errorBody(r.Context(), err, cy.catalog, cy.transformError, cy.converter)
+	setRetryAfter(w.Header(), err)
+	cy.render(w, r, statusCode, obj)
+}
+
+// converterTransformError is the TransformError converting the status code with the converter of the carrier,
+// like `ProblemTransformer`.
+type converterTransformError interface {
+	TransformErrorWith(ctx context.Context, err error, c errorx.Converter) (statusCode int, v any)
+}
+
+// errorBody returns the status code and the body of the localized error,
+// the default body is the `err.Error()` string with status 500,
+// use `ErrorTransformer` or `ProblemTransformer` to render the structured error.
+func errorBody(ctx context.Context, err error, catalog *errorx.Catalog, transformError transport.TransformError, converter errorx.Converter) (int, any) {
+	if catalog != nil {
+		err = catalog.Localize(ctx, err)
+	}
+	if t, ok := transformError.(converterTransformError); ok {
+		return t.TransformErrorWith(ctx, err, converter)
+	}
+	if transformError != nil {
+		return transformError.TransformError(ctx, err)
+	}
+	return http.StatusInternalServerError, err.Error()
+}
+
+// setRetryAfter set the `Retry-After` header(in seconds) if the error is retryable with a delay.
+func setRetryAfter(h http.Header, err error) {
+	if delay, ok := errorx.IsRetryable(err); ok && delay > 0 {
+		h.Set("Retry-After", strconv.FormatInt(int64((delay+time.Second-1)/time.Second), 10))
+	}
+}
+
+func (cy *CarryStd) Render(w http.ResponseWriter, r *http.Request, v any) {
+	if cy.transformBody != nil {
+		v = cy.transformBody.TransformBody(r.Context(), v)
+	}
+	cy.render(w, r, http.StatusOK, v)
+}
+
+// render marshal the body with the encoding corresponding to the `Accept` header, the problem details
+// use the problem content type. the body is marshaled before the header is written,
+// so the `Content-Type` is sent on any `http.ResponseWriter`.
+func (cy *CarryStd) render(w http.ResponseWriter, r *http.Request, statusCode int, v any) {
+	if v == nil {
+		w.WriteHeader(statusCode)
+		return
+	}
+	marshaller := cy.encoding.OutboundForRequest(r)
+	data, err := marshaller.Marshal(v)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Render failed cause by %v", err), http.StatusInternalServerError)
+		return
+	}
+	contentType := marshaller.ContentType(v)
+	if _, ok := v.(*Problem); ok {
+		contentType = problemContentType(contentType)
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(statusCode)
+	_, _ = w.Write(data)
+}
+
+// EncodeEvent encode the server-sent event message with the encoding corresponding to the request.
+func (cy *CarryStd) EncodeEvent(r *http.Request, v any) ([]byte, error) {
+	if cy.transformBody != nil {
+		v = cy.transformBody.TransformBody(r.Context(), v)
+	}
+	return cy.encoding.OutboundForRequest(r).Marshal(v)
+}
+
+// EncodeErrorEvent encode the server-sent event error, the body is same as `Error` renders.
+func (cy *CarryStd) EncodeErrorEvent(r *http.Request, err error) ([]byte, error) {
+	_, obj := errorBody(r.Context(), err, cy.catalog, cy.transformError, cy.converter)
+	return cy.encoding.OutboundForRequest(r).Marshal(obj)
+}
+func (cy *CarryStd) Validator() *validator.Validate {
+	return cy.validation
+}
+func (cy *CarryStd) Validate(ctx context.Context, v any) error {
+	return ValidationError(cy.validation.StructCtx(ctx, v), cy.translator)
+}
+func (cy *CarryStd) StructCtx(ctx context.Context, v any) error {
+	return cy.validation.StructCtx(ctx, v)
+}
+func (cy *CarryStd) Struct(v any) error {
+	return cy.validation.Struct(v)
+}
+func (cy *CarryStd) VarCtx(ctx context.Context, v any, tag string) error {
+	return cy.validation.VarCtx(ctx, v, tag)
+}
+func (cy *CarryStd) Var(v any, tag string) error {
+	return cy.validation.Var(v, tag)
+}
@@ -0,0 +1,84 @@
+package carry_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/things-go/dyn/carry"
+	"github.com/things-go/dyn/errorx"
+	"github.com/things-go/dyn/transport"
+)
+
+func Test_CarryStd_ContentType(t *testing.T) {
+	newRequest := func() *http.Request {
+		r := httptest.NewRequest(http.MethodGet, "/v1/hello", nil)
+		r.Header.Set("Accept", "application/json")
+		return r
+	}
+
+	t.Run("render", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		carry.NewCarryStd().Render(w, newRequest(), map[string]string{"message": "hello"})
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "application/json; charset=utf-8", w.Result().Header.Get("Content-Type"))
+		require.JSONEq(t, `{"message":"hello"}`, w.Body.String())
+	})
+	t.Run("default error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		carry.NewCarryStd().Error(w, newRequest(), errorx.NewNotFound(errorx.WithMessage("not found")))
+		require.Equal(t, http.StatusInternalServerError, w.Code)
+		require.Equal(t, "application/json; charset=utf-8", w.Result().Header.Get("Content-Type"))
+		require.Equal(t, `"not found"`, w.Body.String())
+	})
+	t.Run("error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		cy := carry.NewCarryStd(carry.WithTransformError(carry.NewErrorTransformer()))
+		cy.Error(w, newRequest(), errorx.NewNotFound(errorx.WithMessage("not found")))
+		require.Equal(t, http.StatusNotFound, w.Code)
+		require.Equal(t, "application/json; charset=utf-8", w.Result().Header.Get("Content-Type"))
+		require.JSONEq(t, `{"code":404,"message":"not found"}`, w.Body.String())
+	})
+	t.Run("problem", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		cy := carry.NewCarryStd(carry.WithTransformError(carry.NewProblemTransformer()))
+		cy.Error(w, newRequest(), errorx.NewServiceUnavailable(errorx.WithRetry(1500*time.Millisecond)))
+		require.Equal(t, http.StatusServiceUnavailable, w.Code)
+		require.Equal(t, carry.MIMEProblemJSON+"; charset=utf-8", w.Result().Header.Get("Content-Type"))
+		require.Equal(t, "2", w.Result().Header.Get("Retry-After"))
+	})
+}
+
+func Test_Carry_ErrorRequestId(t *testing.T) {
+	newRequest := func() *http.Request {
+		r := httptest.NewRequest(http.MethodGet, "/v1/hello", nil)
+		r.Header.Set("Accept", "application/json")
+		return r.WithContext(transport.WithValueRequestId(r.Context(), "req-1"))
+	}
+	err := errorx.NewNotFound(errorx.WithMessage("not found"))
+
+	t.Run("std", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		carry.NewCarryStd(carry.WithTransformError(carry.NewErrorTransformer())).Error(w, newRequest(), err)
+		require.Equal(t, http.StatusNotFound, w.Code)
+		require.JSONEq(t, `{"code":404,"message":"not found","request_id":"req-1"}`, w.Body.String())
+		require.Empty(t, err.RequestId())
+	})
+	t.Run("gin", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = newRequest()
+		carry.NewCarryGin(carry.WithTransformError(carry.NewErrorTransformer())).Error(c, err)
+		require.Equal(t, http.StatusNotFound, w.Code)
+		require.JSONEq(t, `{"code":404,"message":"not found","request_id":"req-1"}`, w.Body.String())
+	})
+	t.Run("problem", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		carry.NewCarryStd(carry.WithTransformError(carry.NewProblemTransformer())).Error(w, newRequest(), err)
+		require.Contains(t, w.Body.String(), `"request_id":"req-1"`)
+	})
+}
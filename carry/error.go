@@ -0,0 +1,33 @@
+package carry
+
+import (
+	"context"
+
+	"github.com/things-go/dyn/errorx"
+	"github.com/things-go/dyn/transport"
+)
+
+var _ transport.TransformError = (*ErrorTransformer)(nil)
+
+// ErrorTransformer render any error parsed via `errorx.Parse` as the json of `errorx.Error`,
+// the status code is derived from the error. use it with `WithTransformError`,
+// the status code is converted with the converter of the carrier, see `WithConverter`.
+type ErrorTransformer struct{}
+
+// NewErrorTransformer new errorx.Error transformer
+func NewErrorTransformer() *ErrorTransformer {
+	return &ErrorTransformer{}
+}
+
+// TransformError implement transport.TransformError, the status code is converted with errorx.DefaultConverter.
+func (t *ErrorTransformer) TransformError(ctx context.Context, err error) (int, any) {
+	return t.TransformErrorWith(ctx, err, nil)
+}
+
+// TransformErrorWith same as TransformError, but the status code is converted with the converter,
+// nil means errorx.DefaultConverter.
+// the error is exposed via `errorx.Error.Public` with the request id in context.
+func (t *ErrorTransformer) TransformErrorWith(ctx context.Context, err error, c errorx.Converter) (int, any) {
+	e := errorx.Parse(err).WithContextRequestId(ctx).Public()
+	return e.HTTPStatusWith(c), e
+}
@@ -0,0 +1,157 @@
+package carry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"mime"
+	"net/http"
+
+	"github.com/things-go/encoding"
+
+	"github.com/things-go/dyn/errorx"
+	"github.com/things-go/dyn/transport"
+)
+
+// Content-Type MIME of the problem details, see RFC 9457.
+const (
+	MIMEProblemJSON = "application/problem+json"
+	MIMEProblemXML  = "application/problem+xml"
+)
+
+var _ transport.TransformError = (*ProblemTransformer)(nil)
+
+// Problem is the problem details for HTTP APIs, see RFC 9457.
+// Extensions are rendered as top-level members in json.
+type Problem struct {
+	XMLName    xml.Name       `json:"-" xml:"urn:ietf:rfc:7807 problem"`
+	Type       string         `json:"type,omitempty" xml:"type,omitempty"`
+	Title      string         `json:"title,omitempty" xml:"title,omitempty"`
+	Status     int            `json:"status,omitempty" xml:"status,omitempty"`
+	Detail     string         `json:"detail,omitempty" xml:"detail,omitempty"`
+	Instance   string         `json:"instance,omitempty" xml:"instance,omitempty"`
+	Extensions map[string]any `json:"-" xml:"-"`
+}
+
+// MarshalJSON implement json.Marshaler, the extension members are flattened.
+func (p *Problem) MarshalJSON() ([]byte, error) {
+	type problem Problem
+	b, err := json.Marshal((*problem)(p))
+	if err != nil {
+		return nil, err
+	}
+	ext := make(map[string]any, len(p.Extensions))
+	for k, v := range p.Extensions {
+		switch k {
+		case "type", "title", "status", "detail", "instance":
+		default:
+			ext[k] = v
+		}
+	}
+	if len(ext) == 0 {
+		return b, nil
+	}
+	eb, err := json.Marshal(ext)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.Equal(b, []byte("{}")) {
+		return eb, nil
+	}
+	b = append(b[:len(b)-1], ',')
+	return append(b, eb[1:]...), nil
+}
+
+// problemContentType returns the problem details content type corresponding to the content type.
+// json --> application/problem+json
+// xml  --> application/problem+xml
+// others keep unchanged.
+func problemContentType(contentType string) string {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return contentType
+	}
+	switch mediaType {
+	case encoding.MIMEJSON:
+		return mime.FormatMediaType(MIMEProblemJSON, params)
+	case encoding.MIMEXML, encoding.MIMEXML2:
+		return mime.FormatMediaType(MIMEProblemXML, params)
+	}
+	return contentType
+}
+
+// ProblemOption problem transformer option
+type ProblemOption func(*ProblemTransformer)
+
+// WithProblemType custom the problem type URI, default "about:blank".
+func WithProblemType(f func(e *errorx.Error) string) ProblemOption {
+	return func(t *ProblemTransformer) {
+		t.typeURI = f
+	}
+}
+
+// ProblemTransformer render any error parsed via `errorx.Parse` as problem details.
+// use it with `WithTransformError`, the status code is converted with the converter of the carrier,
+// see `WithConverter`.
+type ProblemTransformer struct {
+	typeURI func(e *errorx.Error) string
+}
+
+// NewProblemTransformer new problem details transformer
+func NewProblemTransformer(opts ...ProblemOption) *ProblemTransformer {
+	t := &ProblemTransformer{
+		typeURI: func(*errorx.Error) string { return "about:blank" },
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// TransformError implement transport.TransformError, the status code is converted with errorx.DefaultConverter.
+func (t *ProblemTransformer) TransformError(ctx context.Context, err error) (int, any) {
+	return t.TransformErrorWith(ctx, err, nil)
+}
+
+// TransformErrorWith same as TransformError, but the status code is converted with the converter,
+// nil means errorx.DefaultConverter.
+// the error is exposed via `errorx.Error.Public`, the sensitive metadata is stripped in release mode.
+// the request id in context is rendered as the `request_id` extension, see `transport.FromRequestId`.
+func (t *ProblemTransformer) TransformErrorWith(ctx context.Context, err error, c errorx.Converter) (int, any) {
+	e := errorx.Parse(err).Public()
+	statusCode := e.HTTPStatusWith(c)
+	p := &Problem{
+		Type:   t.typeURI(e),
+		Title:  http.StatusText(statusCode),
+		Status: statusCode,
+		Detail: e.Message(),
+		Extensions: map[string]any{
+			"code": e.Code(),
+		},
+	}
+	if reason := e.Reason(); reason != "" {
+		p.Extensions["reason"] = reason
+	}
+	if domain := e.Domain(); domain != "" {
+		p.Extensions["domain"] = domain
+	}
+	if tr, ok := transport.FromTransporter(ctx); ok {
+		p.Instance = tr.FullPath()
+	}
+	if id, ok := transport.FromRequestId(ctx); ok {
+		p.Extensions["request_id"] = id
+	}
+	if vs := e.FieldViolations(); len(vs) > 0 {
+		p.Extensions["violations"] = vs
+	}
+	if items := e.Items(); len(items) > 0 {
+		p.Extensions["errors"] = items
+	}
+	for k, v := range e.Metadata() {
+		if _, ok := p.Extensions[k]; !ok {
+			p.Extensions[k] = v
+		}
+	}
+	return statusCode, p
+}
@@ -0,0 +1,125 @@
+package carry_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+
+	"github.com/things-go/dyn/carry"
+	"github.com/things-go/dyn/errorx"
+	transportHttp "github.com/things-go/dyn/transport/http"
+)
+
+func Test_Problem_MarshalJSON(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		problem *carry.Problem
+		want    string
+	}{
+		{
+			name:    "no extensions",
+			problem: &carry.Problem{Type: "about:blank", Title: "Not Found", Status: 404},
+			want:    `{"type":"about:blank","title":"Not Found","status":404}`,
+		},
+		{
+			name: "extensions are flattened",
+			problem: &carry.Problem{
+				Type:       "about:blank",
+				Title:      "Not Found",
+				Status:     404,
+				Extensions: map[string]any{"code": 1001, "uid": "1", "tags": []string{"a"}},
+			},
+			want: `{"type":"about:blank","title":"Not Found","status":404,"code":1001,"tags":["a"],"uid":"1"}`,
+		},
+		{
+			name: "reserved members win over extensions",
+			problem: &carry.Problem{
+				Type:   "about:blank",
+				Status: 404,
+				Extensions: map[string]any{
+					"type": "urn:x", "title": "x", "status": 500, "detail": "x", "instance": "x", "code": 1001,
+				},
+			},
+			want: `{"type":"about:blank","status":404,"code":1001}`,
+		},
+		{
+			name:    "only extensions",
+			problem: &carry.Problem{Extensions: map[string]any{"code": 1001}},
+			want:    `{"code":1001}`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := json.Marshal(tt.problem)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, string(b))
+		})
+	}
+}
+
+func Test_ProblemTransformer(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		statusCode, v := carry.NewProblemTransformer().
+			TransformError(context.Background(), errorx.NewWithStatus(http.StatusNotFound, 1001, "user not found"))
+		require.Equal(t, http.StatusNotFound, statusCode)
+		p, ok := v.(*carry.Problem)
+		require.True(t, ok)
+		require.Equal(t, "about:blank", p.Type)
+		require.Equal(t, "Not Found", p.Title)
+		require.Equal(t, http.StatusNotFound, p.Status)
+		require.Equal(t, "user not found", p.Detail)
+		// not in the http transport.
+		require.Empty(t, p.Instance)
+		require.Equal(t, map[string]any{"code": int32(1001)}, p.Extensions)
+	})
+	t.Run("metadata does not override the code", func(t *testing.T) {
+		_, v := carry.NewProblemTransformer().
+			TransformError(context.Background(), errorx.New(1001, "user not found", errorx.WithMetadata("code", "x"), errorx.WithMetadata("uid", "1")))
+		require.Equal(t, map[string]any{"code": int32(1001), "uid": "1"}, v.(*carry.Problem).Extensions)
+	})
+	t.Run("type and instance", func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		transformer := carry.NewProblemTransformer(carry.WithProblemType(func(e *errorx.Error) string {
+			return "https://example.com/problems/not-found"
+		}))
+		g := gin.New()
+		g.Use(transportHttp.TransportInterceptor())
+		g.GET("/v1/hello/:id", func(c *gin.Context) {
+			carry.NewCarry(carry.WithTransformError(transformer)).Error(c, errorx.NewWithStatus(http.StatusNotFound, 1001, "user not found"))
+		})
+		w := httptest.NewRecorder()
+		g.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/hello/12", nil))
+		require.Equal(t, http.StatusNotFound, w.Code)
+		require.Equal(t, "application/problem+json; charset=utf-8", w.Header().Get("Content-Type"))
+		require.JSONEq(t, `{
+			"type":"https://example.com/problems/not-found",
+			"title":"Not Found",
+			"status":404,
+			"detail":"user not found",
+			"instance":"/v1/hello/12",
+			"code":1001
+		}`, w.Body.String())
+	})
+	t.Run("converter of the carrier", func(t *testing.T) {
+		cy := carry.NewCarryStd(
+			carry.WithTransformError(carry.NewProblemTransformer()),
+			carry.WithConverter(errorx.NewTableConverter(errorx.WithMapping(http.StatusPreconditionFailed, codes.FailedPrecondition))),
+		)
+		w := httptest.NewRecorder()
+		cy.Error(w, httptest.NewRequest(http.MethodGet, "/v1/hello/12", nil),
+			errorx.New(1001, "user disabled", errorx.WithGRPCCode(codes.FailedPrecondition)))
+		require.Equal(t, http.StatusPreconditionFailed, w.Code)
+		require.JSONEq(t, `{
+			"type":"about:blank",
+			"title":"Precondition Failed",
+			"status":412,
+			"detail":"user disabled",
+			"code":1001
+		}`, w.Body.String())
+	})
+}
@@ -0,0 +1,79 @@
+package carry
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/things-go/dyn/errorx"
+)
+
+// newValidation new the default validator, use `binding` tag,
+// field name use the json/proto names.
+func newValidation() *validator.Validate {
+	v := validator.New()
+	v.SetTagName("binding")
+	v.RegisterTagNameFunc(fieldName)
+	return v
+}
+
+// fieldName returns the json/proto name of the field.
+// json tag > protobuf tag json= > protobuf tag name= > go field name.
+func fieldName(fld reflect.StructField) string {
+	if name, _, _ := strings.Cut(fld.Tag.Get("json"), ","); name != "" {
+		if name == "-" {
+			return ""
+		}
+		return name
+	}
+	if tag := fld.Tag.Get("protobuf"); tag != "" {
+		name := ""
+		for _, s := range strings.Split(tag, ",") {
+			if v, ok := strings.CutPrefix(s, "json="); ok {
+				return v
+			}
+			if v, ok := strings.CutPrefix(s, "name="); ok {
+				name = v
+			}
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return fld.Name
+}
+
+// ValidationError convert the `validator.ValidationErrors` into `errorx.NewBadRequest`
+// carrying structured field violations, other errors return unchanged.
+// if trans is not nil, the message of the field violation is translated.
+func ValidationError(err error, trans ut.Translator) error {
+	var ves validator.ValidationErrors
+	if !errors.As(err, &ves) {
+		return err
+	}
+	vs := make([]*errorx.FieldViolation, 0, len(ves))
+	for _, fe := range ves {
+		field := fe.Namespace()
+		// trim the top struct name
+		if _, after, found := strings.Cut(field, "."); found {
+			field = after
+		}
+		msg := ""
+		if trans != nil {
+			msg = fe.Translate(trans)
+		} else {
+			msg = fmt.Sprintf("field validation for '%s' failed on the '%s' tag", field, fe.Tag())
+		}
+		vs = append(vs, &errorx.FieldViolation{
+			Field:   field,
+			Tag:     fe.Tag(),
+			Param:   fe.Param(),
+			Message: msg,
+		})
+	}
+	return errorx.NewBadRequest(errorx.WithFieldViolations(vs...), errorx.WithCause(err))
+}
@@ -18,6 +18,7 @@ type ErrnoOption struct {
 	Tags            []string
 	DisableStringer bool
 	Epk             string
+	Register        bool
 }
 
 type ErrnoCmd struct {
@@ -78,6 +79,7 @@ func NewErrnoCmd() *ErrnoCmd {
 				Tags:      root.Tags,
 				Version:   meta.Version,
 				Epk:       root.Epk,
+				Register:  root.Register,
 			}
 			err = g.Generate()
 			if err != nil {
@@ -91,7 +93,8 @@ func NewErrnoCmd() *ErrnoCmd {
 	cmd.Flags().StringSliceVarP(&root.Type, "type", "t", nil, "the list type of enum names; must be set")
 	cmd.Flags().StringSliceVar(&root.Tags, "tags", nil, "comma-separated list of build tags to apply")
 	cmd.Flags().BoolVarP(&root.DisableStringer, "disable-stringer", "d", false, "disable use `stringer` command.")
-	cmd.Flags().StringVarP(&root.Epk, "epk", "e", "github.com/things-go/dyn/errorx", "errors package import path")
+	cmd.Flags().StringVarP(&root.Epk, "epk", "e", "github.com/things-go/dyn/errorx", "errors package import path, it must provide `Error`, `Option`, `New` and `WithReason`, and `WithStatus`, `WithGRPCCode` if annotated, `MustRegister` if --register")
+	cmd.Flags().BoolVar(&root.Register, "register", false, "register the errors into the errors package registry at init, the zero value is skipped, the source is `<package path>.<type name>`")
 
 	root.Cmd = cmd
 	return root
@@ -10,10 +10,13 @@ import (
 	"os"
 	"slices"
 	"strings"
+
+	"github.com/things-go/dyn/cmd/internal/errnoderive"
 )
 
 type Package struct {
 	Name  string
+	Path  string
 	Defs  map[*ast.Ident]types.Object
 	Files []*File
 }
@@ -31,6 +34,9 @@ type File struct {
 type Value struct {
 	OriginalName string // 常量定义的名称
 	Mapping      string // 注释名称, 如果没有, 则同常量名称
+	Reason       string // 机器可读的原因, 由常量名称转换为大写蛇形, 如 USER_NOT_EXIST
+	Status       int    // http状态码, 0表示未设置, 来自注解 #[errno(status="404")]
+	GrpcCode     string // gRPC状态码`codes.Code`标识, 空表示未设置, 来自注解 #[errno(grpc_code="NOT_FOUND")]
 	// value相关
 	Value  uint64 // 需要时转为`int64`.
 	Signed bool   // `constant`是否是有符号类型.
@@ -66,7 +72,7 @@ func (f *File) GenDecl(node ast.Node) bool {
 	// a list of names possibly followed by a type, possibly followed by values.
 	// If the type and value are both missing, we carry down the type (and value,
 	// but the "go/types" package takes care of that).
-	for _, spec := range decl.Specs {
+	for i, spec := range decl.Specs {
 		if decl.Tok == token.TYPE { // type spec
 			tsepc := spec.(*ast.TypeSpec)          // 必定是 TYPE
 			if tsepc.Name.String() == f.TypeName { // 找到这个类型
@@ -155,6 +161,7 @@ func (f *File) GenDecl(node ast.Node) bool {
 				}
 				v := &Value{
 					OriginalName: name.Name,
+					Reason:       errnoderive.ToReason(name.Name),
 					Value:        u64,
 					Signed:       info&types.IsUnsigned == 0,
 					Val:          value.String(),
@@ -164,6 +171,15 @@ func (f *File) GenDecl(node ast.Node) bool {
 				} else {
 					v.Mapping = v.OriginalName
 				}
+				doc := vspec.Doc
+				if doc == nil && i == 0 && !decl.Lparen.IsValid() {
+					doc = decl.Doc
+				}
+				if doc != nil {
+					annotateErrnoValue, _ := errnoderive.ParseDeriveErrnoValue(doc.Text())
+					v.Status = annotateErrnoValue.Status
+					v.GrpcCode = annotateErrnoValue.GrpcCode
+				}
 				f.Values = append(f.Values, v)
 			}
 		}
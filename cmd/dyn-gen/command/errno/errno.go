@@ -22,6 +22,7 @@ type Gen struct {
 	Version   string
 	pkg       *Package
 	Epk       string
+	Register  bool
 }
 
 func (g *Gen) Generate() error {
@@ -47,6 +48,7 @@ func (g *Gen) Generate() error {
 	pkg := pkgs[0]
 	g.pkg = &Package{
 		Name:  pkg.Name,
+		Path:  pkg.PkgPath,
 		Defs:  pkg.TypesInfo.Defs,
 		Files: make([]*File, len(pkg.Syntax)),
 	}
@@ -94,7 +96,9 @@ func (g *Gen) generateError(typeName string) error {
 		Version:      g.Version,
 		IsDeprecated: false,
 		Package:      g.pkg.Name,
+		PkgPath:      g.pkg.Path,
 		Epk:          g.Epk,
+		Register:     g.Register,
 		Enums: []*Enumerate{
 			{
 				Type:     typeType,
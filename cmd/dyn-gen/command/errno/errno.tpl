@@ -8,13 +8,30 @@ package {{.Package}}
 
 import (
 	errors "{{.Epk}}"
+{{- if .HasGrpcCode}}
+	"google.golang.org/grpc/codes"
+{{- end}}
 )
 {{- range $e := .Enums}}
 
 {{- range $ee := $e.Values}}
 // Err{{$ee.OriginalName}} {{$ee.Value}}: {{.Mapping}}
 func Err{{$ee.OriginalName}}(opts ...errors.Option) *errors.Error {
-	return errors.New(int32({{$ee.OriginalName}}), {{$ee.OriginalName}}.String(), opts...)
+	return errors.New(int32({{$ee.OriginalName}}), {{$ee.OriginalName}}.String(), append([]errors.Option{errors.WithReason("{{$ee.Reason}}")
+		{{- if $ee.Status}}, errors.WithStatus({{$ee.Status}}){{end}}
+		{{- if $ee.GrpcCode}}, errors.WithGRPCCode(codes.{{$ee.GrpcCode}}){{end}}}, opts...)...)
+}
+{{- end}}
+{{- if $.Register}}
+
+func init() {
+	errors.MustRegister("{{$.PkgPath}}.{{$e.TypeName}}",
+	{{- range $ee := $e.Values}}
+	{{- if $ee.Value}}
+		Err{{$ee.OriginalName}}(),
+	{{- end}}
+	{{- end}}
+	)
 }
 {{- end}}
 
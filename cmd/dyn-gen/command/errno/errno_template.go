@@ -17,10 +17,24 @@ type GenFile struct {
 	Version      string
 	IsDeprecated bool
 	Package      string
+	PkgPath      string
 	Epk          string
+	Register     bool
 	Enums        []*Enumerate
 }
 
+// HasGrpcCode return true if any value declare the gRPC code.
+func (e *GenFile) HasGrpcCode() bool {
+	for _, em := range e.Enums {
+		for _, v := range em.Values {
+			if v.GrpcCode != "" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 type Enumerate struct {
 	Type     string
 	TypeName string
@@ -0,0 +1,105 @@
+package errnoderive
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/things-go/proc/proc"
+)
+
+// annotation const value
+const (
+	Identity                 = "errno"
+	Attribute_Name_Status    = "status"
+	Attribute_Name_Grpc_Code = "grpc_code"
+)
+
+// grpcCodeNames gRPC code name(lower case and without underscore) --> `codes.Code` identity
+var grpcCodeNames = map[string]string{
+	"ok":                 "OK",
+	"canceled":           "Canceled",
+	"cancelled":          "Canceled",
+	"unknown":            "Unknown",
+	"invalidargument":    "InvalidArgument",
+	"deadlineexceeded":   "DeadlineExceeded",
+	"notfound":           "NotFound",
+	"alreadyexists":      "AlreadyExists",
+	"permissiondenied":   "PermissionDenied",
+	"resourceexhausted":  "ResourceExhausted",
+	"failedprecondition": "FailedPrecondition",
+	"aborted":            "Aborted",
+	"outofrange":         "OutOfRange",
+	"unimplemented":      "Unimplemented",
+	"internal":           "Internal",
+	"unavailable":        "Unavailable",
+	"dataloss":           "DataLoss",
+	"unauthenticated":    "Unauthenticated",
+}
+
+// ErrnoValueDerive errno value annotation.
+//
+//	#[errno(status="404", grpc_code="NOT_FOUND")]
+type ErrnoValueDerive struct {
+	Status   int    // http status, 0 means not set.
+	GrpcCode string // `codes.Code` identity, like NotFound, empty means not set.
+}
+
+// ParseDeriveErrnoValue parse the errno value annotation from the comments.
+func ParseDeriveErrnoValue(s string) (*ErrnoValueDerive, proc.CommentLines) {
+	ret := &ErrnoValueDerive{Status: 0, GrpcCode: ""}
+	derives, remainComments := proc.NewCommentLines(s).FindDerives(Identity)
+	statusValues := proc.Derives(derives).FindValue(Identity, Attribute_Name_Status)
+	for _, v := range statusValues {
+		if v, ok := v.(proc.String); ok {
+			if status, err := strconv.Atoi(strings.TrimSpace(v.Value)); err == nil {
+				ret.Status = status
+				break
+			}
+		}
+	}
+	grpcCodeValues := proc.Derives(derives).FindValue(Identity, Attribute_Name_Grpc_Code)
+	for _, v := range grpcCodeValues {
+		if v, ok := v.(proc.String); ok {
+			if code, ok := ToGrpcCodeIdent(v.Value); ok {
+				ret.GrpcCode = code
+				break
+			}
+		}
+	}
+	return ret, remainComments
+}
+
+// ToGrpcCodeIdent returns the `codes.Code` identity of the gRPC code name,
+// it supports NOT_FOUND, NotFound, not_found and so on.
+func ToGrpcCodeIdent(name string) (string, bool) {
+	key := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(name), "_", ""))
+	ident, ok := grpcCodeNames[key]
+	return ident, ok
+}
+
+// ToReason returns the UPPER_SNAKE reason of the enum value name,
+// like UserNotExist, userNotExist, user_not_exist, USER_NOT_EXIST --> USER_NOT_EXIST,
+// HTTPTimeout --> HTTP_TIMEOUT.
+func ToReason(name string) string {
+	rs := []rune(strings.TrimSpace(name))
+	b := strings.Builder{}
+	b.Grow(len(rs) + 4)
+	for i, r := range rs {
+		if r == '_' || r == '-' || r == ' ' {
+			if b.Len() > 0 && !strings.HasSuffix(b.String(), "_") {
+				b.WriteByte('_')
+			}
+			continue
+		}
+		if i > 0 && unicode.IsUpper(r) && b.Len() > 0 && !strings.HasSuffix(b.String(), "_") {
+			prev := rs[i-1]
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) ||
+				(unicode.IsUpper(prev) && i+1 < len(rs) && unicode.IsLower(rs[i+1])) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return strings.TrimSuffix(b.String(), "_")
+}
@@ -8,12 +8,14 @@ import (
 	"google.golang.org/protobuf/compiler/protogen"
 	"google.golang.org/protobuf/types/pluginpb"
 
+	"github.com/things-go/dyn/cmd/internal/errnoderive"
 	"github.com/things-go/dyn/cmd/internal/protoutil"
 	"github.com/things-go/proc/infra"
 )
 
 const (
-	fmtPackage = protogen.GoImportPath("fmt")
+	fmtPackage   = protogen.GoImportPath("fmt")
+	codesPackage = protogen.GoImportPath("google.golang.org/grpc/codes")
 )
 
 func runProtoGen(gen *protogen.Plugin) error {
@@ -77,15 +79,26 @@ func generateFileContent(gen *protogen.Plugin, file *protogen.File, g *protogen.
 }
 
 func genErrorsDetail(gen *protogen.Plugin, file *protogen.File, g *protogen.GeneratedFile, enum *protogen.Enum) bool {
-	var ew errorWrapper
+	ew := errorWrapper{
+		Source:   string(enum.GoIdent.GoImportPath) + "." + enum.GoIdent.GoName,
+		Register: *enableRegister,
+	}
 	for _, v := range enum.Values {
 		msg := strings.ReplaceAll(strings.ReplaceAll(strings.TrimSpace(strings.TrimSuffix(string(v.Comments.Trailing), "\n")), "\n", ","), `"`, `\"`)
+		annotateErrnoValue, _ := errnoderive.ParseDeriveErrnoValue(string(v.Comments.Leading))
+		grpcCode := ""
+		if annotateErrnoValue.GrpcCode != "" {
+			grpcCode = g.QualifiedGoIdent(codesPackage.Ident(annotateErrnoValue.GrpcCode))
+		}
 		err := &errorInfo{
 			Name:       string(enum.Desc.Name()),
 			Code:       int(v.Desc.Number()),
 			Value:      string(v.Desc.Name()),
 			CamelValue: infra.PascalCase(string(v.Desc.Name())),
 			Message:    msg,
+			Reason:     errnoderive.ToReason(string(v.Desc.Name())),
+			Status:     annotateErrnoValue.Status,
+			GrpcCode:   grpcCode,
 		}
 		ew.Errors = append(ew.Errors, err)
 	}
@@ -3,6 +3,18 @@ func Is{{.CamelValue}}(err error) bool {
 	return errorx.EqualCode(err, {{.Code}})
 }
 func Err{{.CamelValue}}() *errorx.Error {
-	return errorx.New({{.Code}}, "{{.Message}}")
+	return errorx.New({{.Code}}, "{{.Message}}", errorx.WithReason("{{.Reason}}"){{if .Status}}, errorx.WithStatus({{.Status}}){{end}}{{if .GrpcCode}}, errorx.WithGRPCCode({{.GrpcCode}}){{end}})
+}
+{{- end }}
+{{- if .Register }}
+
+func init() {
+	errorx.MustRegister("{{.Source}}",
+{{- range .Errors }}
+{{- if .Code }}
+		Err{{.CamelValue}}(),
+{{- end }}
+{{- end }}
+	)
 }
 {{- end }}
\ No newline at end of file
@@ -11,6 +11,7 @@ const version = "v0.1.2"
 
 var showVersion = flag.Bool("version", false, "print the version and exit")
 var errorsPackage = flag.String("epk", "github.com/things-go/dyn/errorx", "errors core package in your project")
+var enableRegister = flag.Bool("register", false, "register the errors into the errors core package registry at init, the zero value is skipped, the source is `<go import path>.<enum name>`")
 
 func main() {
 	flag.Parse()
@@ -18,10 +18,15 @@ type errorInfo struct {
 	Value      string
 	CamelValue string
 	Message    string
+	Reason     string // machine-readable reason, UPPER_SNAKE enum value name.
+	Status     int    // http status, 0 means not set.
+	GrpcCode   string // qualified `codes.Code` identity, empty means not set.
 }
 
 type errorWrapper struct {
-	Errors []*errorInfo
+	Source   string // enum go import path and name, like github.com/things-go/dyn/example/errnop.ErrorReason
+	Register bool   // register the errors at init, the zero value is skipped
+	Errors   []*errorInfo
 }
 
 func (e *errorWrapper) execute(w io.Writer) error {
@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"go/token"
 	"net/http"
 	"os"
 	"strings"
@@ -18,18 +19,34 @@ import (
 
 const deprecationComment = "// Deprecated: Do not use."
 
+// generation mode
+const (
+	// modeGin register the handlers on gin.
+	modeGin = "gin"
+	// modeStd register the handlers on net/http ServeMux(Go 1.22+), through the framework-neutral carrier.
+	modeStd = "std"
+)
+
 var (
 	errorsPackage        = protogen.GoImportPath("errors")
+	fmtPackage           = protogen.GoImportPath("fmt")
 	contextPackage       = protogen.GoImportPath("context")
 	ginPackage           = protogen.GoImportPath("github.com/gin-gonic/gin")
+	transportPackage     = protogen.GoImportPath("github.com/things-go/dyn/transport")
 	transportHttpPackage = protogen.GoImportPath("github.com/things-go/dyn/transport/http")
-	// netHttpPackage       = protogen.GoImportPath("net/http")
+	netHttpPackage       = protogen.GoImportPath("net/http")
+	nethttpPackage       = protogen.GoImportPath("github.com/things-go/dyn/transport/nethttp")
+	grpcPackage          = protogen.GoImportPath("google.golang.org/grpc")
+	errorxPackage        = protogen.GoImportPath("github.com/things-go/dyn/errorx")
 )
 
 var methodSets = make(map[string]int)
 
 func runProtoGen(gen *protogen.Plugin) error {
 	gen.SupportedFeatures = uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL)
+	if args.Mode != modeGin && args.Mode != modeStd {
+		return fmt.Errorf("protoc-gen-dyn-gin: unknown mode %q, should be one of %q, %q", args.Mode, modeGin, modeStd)
+	}
 	for _, f := range gen.Files {
 		if !f.Generate {
 			continue
@@ -39,12 +56,15 @@ func runProtoGen(gen *protogen.Plugin) error {
 	return nil
 }
 
-// generateFile generates a .gin.pb.go file.
+// generateFile generates a .gin.pb.go file, or a .http.pb.go file in std mode.
 func generateFile(gen *protogen.Plugin, file *protogen.File, omitempty bool) *protogen.GeneratedFile {
 	if len(file.Services) == 0 || (omitempty && !hasHTTPRule(file.Services)) {
 		return nil
 	}
 	filename := file.GeneratedFilenamePrefix + ".gin.pb.go"
+	if args.Mode == modeStd {
+		filename = file.GeneratedFilenamePrefix + ".http.pb.go"
+	}
 	g := gen.NewGeneratedFile(filename, file.GoImportPath)
 	g.P("// Code generated by protoc-gen-dyn-gin. DO NOT EDIT.")
 	g.P("// versions:")
@@ -71,9 +91,15 @@ func generateFileContent(gen *protogen.Plugin, file *protogen.File, g *protogen.
 	g.P("// is compatible.")
 	g.P("var _ = ", errorsPackage.Ident("New"))
 	g.P("var _ = ", contextPackage.Ident("TODO"))
-	g.P("var _ = ", ginPackage.Ident("New"))
-	// g.P("var _ = ", netHttpPackage.Ident("HandleFunc"))
-	g.P("var _ = ", transportHttpPackage.Ident("FromCarrier"))
+	if args.Mode == modeStd {
+		g.P("var _ = ", netHttpPackage.Ident("NewServeMux"))
+		g.P("var _ = ", transportPackage.Ident("Chain"))
+		g.P("var _ = ", nethttpPackage.Ident("FromCarrier"))
+	} else {
+		g.P("var _ = ", ginPackage.Ident("New"))
+		g.P("var _ = ", transportPackage.Ident("Chain"))
+		g.P("var _ = ", transportHttpPackage.Ident("FromCarrier"))
+	}
 	g.P()
 
 	for _, service := range file.Services {
@@ -100,7 +126,11 @@ func genService(gen *protogen.Plugin, file *protogen.File, g *protogen.Generated
 		UseEncoding:     args.UseEncoding,
 	}
 	for _, method := range service.Methods {
-		if method.Desc.IsStreamingClient() || method.Desc.IsStreamingServer() {
+		if !isSupportedMethod(method) {
+			if method.Desc.IsStreamingServer() && !method.Desc.IsStreamingClient() {
+				_, _ = fmt.Fprintf(os.Stderr,
+					"\u001B[31mWARN\u001B[m: %s server-streaming is not supported in mode=std, skipped.\n", method.Desc.FullName())
+			}
 			continue
 		}
 		rule, ok := proto.GetExtension(method.Desc.Options(), annotations.E_Http).(*annotations.HttpRule)
@@ -127,7 +157,7 @@ func genService(gen *protogen.Plugin, file *protogen.File, g *protogen.Generated
 func hasHTTPRule(services []*protogen.Service) bool {
 	for _, service := range services {
 		for _, method := range service.Methods {
-			if method.Desc.IsStreamingClient() || method.Desc.IsStreamingServer() {
+			if !isSupportedMethod(method) {
 				continue
 			}
 			rule, ok := proto.GetExtension(method.Desc.Options(), annotations.E_Http).(*annotations.HttpRule)
@@ -139,6 +169,14 @@ func hasHTTPRule(services []*protogen.Service) bool {
 	return false
 }
 
+// isSupportedMethod the unary method, and the server-streaming method over the server-sent events in gin mode.
+func isSupportedMethod(m *protogen.Method) bool {
+	if m.Desc.IsStreamingClient() {
+		return false
+	}
+	return !m.Desc.IsStreamingServer() || args.Mode == modeGin
+}
+
 func buildHTTPRule(g *protogen.GeneratedFile, m *protogen.Method, rule *annotations.HttpRule) *methodDesc {
 	var (
 		path         string
@@ -205,7 +243,10 @@ func buildHTTPRule(g *protogen.GeneratedFile, m *protogen.Method, rule *annotati
 		md.HasBody = false
 		_, _ = fmt.Fprintf(os.Stderr, "\u001B[31mWARN\u001B[m: %s %s is does not declare a body.\n", method, path)
 	}
-	if responseBody == "*" {
+	if responseBody != "" && md.IsStreaming {
+		_, _ = fmt.Fprintf(os.Stderr,
+			"\u001B[31mWARN\u001B[m: %s %s response_body is ignored by the server-streaming method.\n", method, path)
+	} else if responseBody == "*" {
 		md.ResponseBody = ""
 	} else if responseBody != "" {
 		md.ResponseBody = "." + camelCaseVars(responseBody)
@@ -258,12 +299,35 @@ func buildMethodDesc(g *protogen.GeneratedFile, m *protogen.Method, method, path
 		LeadingComment:  leadingComment,
 		TrailingComment: trailingComment,
 		Comment:         comment,
-		Path:            transformPathParams(path),
+		IsStreaming:     m.Desc.IsStreamingServer(),
+		Path:            transformPath(path),
 		Method:          method,
 		HasVars:         len(vars) > 0,
 	}
 }
 
+// transformPath transform the path into the route of the mode.
+// gin: {xx} --> :xx
+// std: {xx} keep unchanged, the ServeMux wildcard.
+func transformPath(path string) string {
+	if args.Mode == modeStd {
+		return transformPathWildcards(path)
+	}
+	return transformPathParams(path)
+}
+
+// transformPathWildcards check the path params are valid ServeMux wildcards, {xx} keep unchanged.
+func transformPathWildcards(path string) string {
+	for _, v := range buildPathVars(nil, path) {
+		if !token.IsIdentifier(v) {
+			// nolint: lll
+			fmt.Fprintf(os.Stderr, "\u001B[31mERROR\u001B[m: The path param '%s' in '%s' should be a valid Go identifier in std mode, ServeMux wildcard requires.\n", v, path)
+			os.Exit(2) // nolint: gocritic
+		}
+	}
+	return path
+}
+
 // transformPathParams 路由路由 {xx} --> :xx
 func transformPathParams(path string) string {
 	paths := strings.Split(path, "/")
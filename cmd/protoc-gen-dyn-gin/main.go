@@ -15,6 +15,7 @@ var args = struct {
 	AllowEmptyPatchBody bool
 	UseEncoding         bool
 	EnableMetadata      bool
+	Mode                string
 }{
 	ShowVersion:         false,
 	Omitempty:           true,
@@ -22,6 +23,7 @@ var args = struct {
 	AllowEmptyPatchBody: false,
 	UseEncoding:         false,
 	EnableMetadata:      false,
+	Mode:                modeGin,
 }
 
 func init() {
@@ -31,6 +33,7 @@ func init() {
 	flag.BoolVar(&args.AllowEmptyPatchBody, "allow_empty_patch_body", false, "allow empty patch body")
 	flag.BoolVar(&args.UseEncoding, "use_encoding", false, "use the framework encoding")
 	flag.BoolVar(&args.EnableMetadata, "enable_metadata", false, "store the metadata for every router.")
+	flag.StringVar(&args.Mode, "mode", modeGin, "generation mode, gin: register on gin, std: register on net/http ServeMux(Go 1.22+).")
 }
 
 func main() {
@@ -29,6 +29,7 @@ type methodDesc struct {
 	LeadingComment  string // leading comment
 	TrailingComment string // trailing comment
 	Comment         string // combine leading and trailing comment
+	IsStreaming     bool   // server-streaming or not, over the server-sent events
 
 	// http_rule
 	Path         string // 路径
@@ -65,11 +66,24 @@ func executeServiceDesc(g *protogen.GeneratedFile, s *serviceDesc) error {
 	}
 	g.P("}")
 	g.P()
+	if args.Mode == modeStd {
+		executeStdServer(g, s)
+	} else {
+		executeGinServer(g, s)
+	}
+	return nil
+}
+
+// executeGinServer generates the register function and the handlers on gin.
+func executeGinServer(g *protogen.GeneratedFile, s *serviceDesc) {
 	// register http server handler
 	if s.Deprecated {
 		g.P(deprecationComment)
 	}
-	g.P("func Register", s.ServiceType, "HTTPServer(g *", g.QualifiedGoIdent(ginPackage.Ident("RouterGroup")), ", srv ", serverInterfaceName(s.ServiceType), ") {")
+	g.P("// Register", s.ServiceType, "HTTPServer register the http handlers,")
+	g.P("// the service methods are invoked through the middlewares.")
+	g.P("func Register", s.ServiceType, "HTTPServer(g *", g.QualifiedGoIdent(ginPackage.Ident("RouterGroup")), ", srv ", serverInterfaceName(s.ServiceType), ", ms ...", g.QualifiedGoIdent(transportPackage.Ident("Middleware")), ") {")
+	g.P("m := ", g.QualifiedGoIdent(transportPackage.Ident("Chain")), "(ms...)")
 	g.P(`r := g.Group("")`)
 	g.P("{")
 	for _, m := range s.Methods {
@@ -82,7 +96,7 @@ func executeServiceDesc(g *protogen.GeneratedFile, s *serviceDesc) error {
 				"{Service: " + serviceTypeMetadataKey(s.ServiceType) + ", Method: \"" + methodMetadataValue(m.Name, m.LeadingComment) + "\"}" +
 				"), "
 		}
-		g.P("r.", m.Method, `("`, m.Path, `", `, useMdMiddleware, serverHandlerMethodName(s.ServiceType, m), "(srv))")
+		g.P("r.", m.Method, `("`, m.Path, `", `, useMdMiddleware, serverHandlerMethodName(s.ServiceType, m), "(srv, m))")
 
 	}
 	g.P("}")
@@ -93,126 +107,130 @@ func executeServiceDesc(g *protogen.GeneratedFile, s *serviceDesc) error {
 		if m.Deprecated {
 			g.P(deprecationComment)
 		}
-		g.P("func ", serverHandlerMethodName(s.ServiceType, m), "(srv ", s.ServiceType, "HTTPServer", ") ", g.QualifiedGoIdent(ginPackage.Ident("HandlerFunc")), " {")
+		g.P("func ", serverHandlerMethodName(s.ServiceType, m), "(srv ", s.ServiceType, "HTTPServer", ", m ", g.QualifiedGoIdent(transportPackage.Ident("Middleware")), ") ", g.QualifiedGoIdent(ginPackage.Ident("HandlerFunc")), " {")
+		if !m.IsStreaming {
+			g.P("h := m(func(ctx ", g.QualifiedGoIdent(contextPackage.Ident("Context")), ", req any) (any, error) {")
+			executeAssertRequest(g, m)
+			g.P("return srv.", m.Name, "(ctx, in)")
+			g.P("})")
+		}
 		{ // gin.HandleFunc closure
 			g.P("return func(c *", g.QualifiedGoIdent(ginPackage.Ident("Context")), ") {")
 			g.P("var err error")
 			g.P("var req ", m.Request)
-			g.P("var reply *", m.Reply)
 			g.P()
 			g.P("carrier := ", g.QualifiedGoIdent(transportHttpPackage.Ident("FromCarrier")), "(c.Request.Context())")
-			{ // binding
-				if s.UseEncoding {
-					if m.HasBody {
-						if m.Body == "" {
-							if m.HasVars {
-								g.P("if err = carrier.ShouldBindQueryBodyUri(c, &req); err != nil {")
-								g.P("carrier.Error(c, err)")
-								g.P("return")
-								g.P("}")
-							} else {
-								g.P("if err = carrier.ShouldBindQueryBody(c, &req); err != nil {")
-								g.P("carrier.Error(c, err)")
-								g.P("return")
-								g.P("}")
-							}
-						} else {
-							g.P("shouldBind := func(req *", m.Request, ") error {")
-							g.P("if err := carrier.BindQuery(c, req); err != nil {")
-							g.P("return err")
-							g.P("}")
-							g.P("if err := carrier.Bind(c, &req", m.Body, "); err != nil {")
-							g.P("return err")
-							g.P("}")
-							if m.HasVars {
-								g.P("if err := carrier.BindUri(c, req); err != nil {")
-								g.P("return err")
-								g.P("}")
-							}
-							g.P("return carrier.Validate(c.Request.Context(), req)")
-							g.P("}")
-							g.P()
-							g.P("if err = shouldBind(&req); err != nil {")
-							g.P("carrier.Error(c, err)")
-							g.P("return")
-							g.P("}")
-						}
-					} else {
-						if m.HasVars {
-							g.P("if err = carrier.ShouldBindQueryUri(c, &req); err != nil {")
-							g.P("carrier.Error(c, err)")
-							g.P("return")
-							g.P("}")
-						} else {
-							g.P("if err = carrier.ShouldBindQuery(c, &req); err != nil {")
-							g.P("carrier.Error(c, err)")
-							g.P("return")
-							g.P("}")
-						}
-					}
-				} else {
-					if m.HasBody {
-						if m.Body == "" {
-							if m.HasVars {
-								g.P("if err = c.ShouldBindQueryBodyUri(&req); err != nil {")
-								g.P("carrier.Error(c, err)")
-								g.P("return")
-								g.P("}")
-							} else {
-								g.P("if err = c.ShouldBindQueryBody(&req); err != nil {")
-								g.P("carrier.Error(c, err)")
-								g.P("return")
-								g.P("}")
-							}
-						} else {
-							g.P("shouldBind := func(req *", m.Request, ") error {")
-							g.P("if err := c.BindQuery(req); err != nil {")
-							g.P("return err")
-							g.P("}")
-							g.P("if err := c.Bind(&req", m.Body, "); err != nil {")
-							g.P("return err")
-							g.P("}")
-							if m.HasVars {
-								g.P("if err := c.BindUri(req); err != nil {")
-								g.P("return err")
-								g.P("}")
-							}
-							g.P("return carrier.Validate(c.Request.Context(), req)")
-							g.P("}")
-							g.P()
-							g.P("if err = shouldBind(&req); err != nil {")
-							g.P("carrier.Error(c, err)")
-							g.P("return")
-							g.P("}")
-						}
-					} else {
-						if m.HasVars {
-							g.P("if err = c.ShouldBindQueryUri(&req); err != nil {")
-							g.P("carrier.Error(c, err)")
-							g.P("return")
-							g.P("}")
-						} else {
-							g.P("if err = c.ShouldBindQuery(&req); err != nil {")
-							g.P("carrier.Error(c, err)")
-							g.P("return")
-							g.P("}")
-						}
-					}
-				}
+			form := ginCallForm
+			if !s.UseEncoding {
+				form = ginContextCallForm
+			}
+			executeBinding(g, m, form)
+			if m.IsStreaming {
+				// the messages are sent as the server-sent events, the error as the error event once any is sent.
+				g.P("stream := ", g.QualifiedGoIdent(transportHttpPackage.Ident("NewEventStream")), "[", m.Reply, "](c)")
+				g.P("h := m(func(ctx ", g.QualifiedGoIdent(contextPackage.Ident("Context")), ", req any) (any, error) {")
+				executeAssertRequest(g, m)
+				g.P("return nil, srv.", m.Name, "(in, stream.WithContext(ctx))")
+				g.P("})")
+				g.P("if _, err = h(c.Request.Context(), &req); err != nil {")
+				g.P("stream.Error(err)")
+				g.P("}")
+			} else {
+				executeDispatch(g, m, form)
 			}
-			g.P("reply, err = srv.", m.Name, "(c.Request.Context(), &req)")
-			g.P("if err != nil {")
-			g.P("carrier.Error(c, err)")
-			g.P("return")
 			g.P("}")
-			g.P("carrier.Render(c, reply", m.ResponseBody, ")")
+		}
+		g.P("}")
+		g.P()
+	}
+}
+
+// callForm the form of the calls in the handler closure, which is the only difference of the binding
+// and the dispatch between the modes.
+type callForm struct {
+	binder      string // the receiver of the binding, the carrier or the gin context.
+	bindArgs    string // the arguments of the binding before the value, empty if the binder is the gin context.
+	carrierArgs string // the arguments of the carrier Error and Render before the value.
+	ctx         string // the request context.
+}
+
+var (
+	// ginCallForm binds through the carrier on gin.
+	ginCallForm = callForm{binder: "carrier", bindArgs: "c, ", carrierArgs: "c", ctx: "c.Request.Context()"}
+	// ginContextCallForm binds through the gin context.
+	ginContextCallForm = callForm{binder: "c", bindArgs: "", carrierArgs: "c", ctx: "c.Request.Context()"}
+	// stdCallForm binds through the carrier on net/http.
+	stdCallForm = callForm{binder: "carrier", bindArgs: "r, ", carrierArgs: "w, r", ctx: "r.Context()"}
+)
+
+// executeBinding generates the binding of the request, the error is rendered by the carrier.
+func executeBinding(g *protogen.GeneratedFile, m *methodDesc, f callForm) {
+	bind := func(method, value string) string {
+		return f.binder + "." + method + "(" + f.bindArgs + value + ")"
+	}
+	switch {
+	case m.HasBody && m.Body == "":
+		if m.HasVars {
+			executeCheckError(g, bind("ShouldBindQueryBodyUri", "&req"), f)
+		} else {
+			executeCheckError(g, bind("ShouldBindQueryBody", "&req"), f)
+		}
+	case m.HasBody:
+		g.P("shouldBind := func(req *", m.Request, ") error {")
+		g.P("if err := ", bind("BindQuery", "req"), "; err != nil {")
+		g.P("return err")
+		g.P("}")
+		g.P("if err := ", bind("Bind", "&req"+m.Body), "; err != nil {")
+		g.P("return err")
+		g.P("}")
+		if m.HasVars {
+			g.P("if err := ", bind("BindUri", "req"), "; err != nil {")
+			g.P("return err")
 			g.P("}")
 		}
+		g.P("return carrier.Validate(", f.ctx, ", req)")
 		g.P("}")
 		g.P()
+		executeCheckError(g, "shouldBind(&req)", f)
+	case m.HasVars:
+		executeCheckError(g, bind("ShouldBindQueryUri", "&req"), f)
+	default:
+		executeCheckError(g, bind("ShouldBindQuery", "&req"), f)
 	}
+}
 
-	return nil
+// executeAssertRequest generates the assertion of the request passed through the middlewares as `in`,
+// returns the internal server error if a middleware replaces it with another type.
+func executeAssertRequest(g *protogen.GeneratedFile, m *methodDesc) {
+	g.P("in, ok := req.(*", m.Request, ")")
+	g.P("if !ok {")
+	g.P("return nil, ", g.QualifiedGoIdent(errorxPackage.Ident("NewInternalServer")), "(",
+		g.QualifiedGoIdent(errorxPackage.Ident("WithCause")), "(",
+		g.QualifiedGoIdent(fmtPackage.Ident("Errorf")), "(\"unexpected request type %T, want *", m.Request, "\", req)))")
+	g.P("}")
+}
+
+// executeDispatch generates the invoking of the unary method through the middlewares and the rendering of the reply.
+func executeDispatch(g *protogen.GeneratedFile, m *methodDesc, f callForm) {
+	g.P("out, err := h(", f.ctx, ", &req)")
+	g.P("if err != nil {")
+	g.P("carrier.Error(", f.carrierArgs, ", err)")
+	g.P("return")
+	g.P("}")
+	g.P("reply, ok := out.(*", m.Reply, ")")
+	g.P("if !ok {")
+	g.P("carrier.Error(", f.carrierArgs, ", ", g.QualifiedGoIdent(fmtPackage.Ident("Errorf")), "(\"unexpected reply type %T, want *", m.Reply, "\", out))")
+	g.P("return")
+	g.P("}")
+	g.P("carrier.Render(", f.carrierArgs, ", reply", m.ResponseBody, ")")
+}
+
+// executeCheckError generates the assignment of the err, returns after rendering if any.
+func executeCheckError(g *protogen.GeneratedFile, expr string, f callForm) {
+	g.P("if err = ", expr, "; err != nil {")
+	g.P("carrier.Error(", f.carrierArgs, ", err)")
+	g.P("return")
+	g.P("}")
 }
 
 func serviceTypeMetadataKey(serverType string) string {
@@ -238,7 +256,12 @@ func serverInterfaceName(serverType string) string {
 	return serverType + "HTTPServer"
 }
 
+// serverMethodName the method signature of the interface,
+// the server-streaming method is same as the gRPC generated, so the implementation serves both.
 func serverMethodName(g *protogen.GeneratedFile, m *methodDesc) string {
+	if m.IsStreaming {
+		return m.Name + "(*" + m.Request + ", " + g.QualifiedGoIdent(grpcPackage.Ident("ServerStreamingServer")) + "[" + m.Reply + "]) error"
+	}
 	return m.Name + "(" + g.QualifiedGoIdent(contextPackage.Ident("Context")) + ", *" + m.Request + ") (*" + m.Reply + ", error)"
 }
 
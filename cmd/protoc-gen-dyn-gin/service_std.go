@@ -0,0 +1,55 @@
+package main
+
+import (
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// executeStdServer generates the register function and the handlers on net/http ServeMux(Go 1.22+),
+// the request is bound, validated and rendered through the framework-neutral carrier.
+func executeStdServer(g *protogen.GeneratedFile, s *serviceDesc) {
+	// register http server handler
+	if s.Deprecated {
+		g.P(deprecationComment)
+	}
+	g.P("// Register", s.ServiceType, "HTTPServer register the http handlers on the ServeMux,")
+	g.P("// the service methods are invoked through the middlewares.")
+	g.P("func Register", s.ServiceType, "HTTPServer(mux *", g.QualifiedGoIdent(netHttpPackage.Ident("ServeMux")), ", srv ", serverInterfaceName(s.ServiceType), ", ms ...", g.QualifiedGoIdent(transportPackage.Ident("Middleware")), ") {")
+	g.P("m := ", g.QualifiedGoIdent(transportPackage.Ident("Chain")), "(ms...)")
+	for _, m := range s.Methods {
+		handler := serverHandlerMethodName(s.ServiceType, m) + "(srv, m)"
+		if args.EnableMetadata {
+			handler = "" +
+				g.QualifiedGoIdent(nethttpPackage.Ident("MetadataInterceptor")) +
+				"(" +
+				g.QualifiedGoIdent(nethttpPackage.Ident("Metadata")) +
+				"{Service: " + serviceTypeMetadataKey(s.ServiceType) + ", Method: \"" + methodMetadataValue(m.Name, m.LeadingComment) + "\"}" +
+				", " + handler + ")"
+		}
+		g.P(`mux.Handle("`, m.Method, " ", m.Path, `", `, handler, ")")
+	}
+	g.P("}")
+	g.P()
+	// handler
+	for _, m := range s.Methods {
+		if m.Deprecated {
+			g.P(deprecationComment)
+		}
+		g.P("func ", serverHandlerMethodName(s.ServiceType, m), "(srv ", s.ServiceType, "HTTPServer", ", m ", g.QualifiedGoIdent(transportPackage.Ident("Middleware")), ") ", g.QualifiedGoIdent(netHttpPackage.Ident("Handler")), " {")
+		g.P("h := m(func(ctx ", g.QualifiedGoIdent(contextPackage.Ident("Context")), ", req any) (any, error) {")
+		executeAssertRequest(g, m)
+		g.P("return srv.", m.Name, "(ctx, in)")
+		g.P("})")
+		{ // http.HandlerFunc closure
+			g.P("return ", g.QualifiedGoIdent(netHttpPackage.Ident("HandlerFunc")), "(func(w ", g.QualifiedGoIdent(netHttpPackage.Ident("ResponseWriter")), ", r *", g.QualifiedGoIdent(netHttpPackage.Ident("Request")), ") {")
+			g.P("var err error")
+			g.P("var req ", m.Request)
+			g.P()
+			g.P("carrier := ", g.QualifiedGoIdent(nethttpPackage.Ident("FromCarrier")), "(r.Context())")
+			executeBinding(g, m, stdCallForm)
+			executeDispatch(g, m, stdCallForm)
+			g.P("})")
+		}
+		g.P("}")
+		g.P()
+	}
+}
@@ -0,0 +1,477 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+
+	"github.com/things-go/dyn/cmd/internal/protoenum"
+)
+
+func runProtoGen(gen *protogen.Plugin) error {
+	gen.SupportedFeatures = uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL)
+	for _, f := range gen.Files {
+		if !f.Generate {
+			continue
+		}
+		for _, service := range f.Services {
+			if err := generateFile(gen, f, service); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// generateFile generates a .openapi.json file per service.
+func generateFile(gen *protogen.Plugin, file *protogen.File, service *protogen.Service) error {
+	b := &builder{schemas: make(map[string]*Schema)}
+	doc := b.buildDocument(service)
+	if len(doc.Paths) == 0 {
+		return nil
+	}
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	filename := file.GeneratedFilenamePrefix + "_" + strings.ToLower(service.GoName) + ".openapi.json"
+	g := gen.NewGeneratedFile(filename, file.GoImportPath)
+	_, err = g.Write(append(data, '\n'))
+	return err
+}
+
+// builder builds the document of a service, the schemas of the messages and enums are collected in components.
+type builder struct {
+	schemas map[string]*Schema
+}
+
+func (b *builder) buildDocument(service *protogen.Service) *Document {
+	serviceDeprecated := service.Desc.Options().(*descriptorpb.ServiceOptions).GetDeprecated()
+	serviceComment := commentText(service.Comments.Leading, service.Comments.Trailing)
+	doc := &Document{
+		OpenAPI: openapiVersion,
+		Info: &Info{
+			Title:       string(service.Desc.FullName()),
+			Description: serviceComment,
+			Version:     args.ApiVersion,
+		},
+		Tags:  []*Tag{{Name: service.GoName, Description: serviceComment}},
+		Paths: make(map[string]*PathItem),
+	}
+	for _, method := range service.Methods {
+		// streaming is not a request/response http api.
+		if method.Desc.IsStreamingClient() || method.Desc.IsStreamingServer() {
+			continue
+		}
+		var rules []*annotations.HttpRule
+		rule, ok := proto.GetExtension(method.Desc.Options(), annotations.E_Http).(*annotations.HttpRule)
+		if rule != nil && ok {
+			rules = append(rules, rule)
+			rules = append(rules, rule.AdditionalBindings...)
+		} else if !args.Omitempty {
+			rules = append(rules, &annotations.HttpRule{
+				Pattern: &annotations.HttpRule_Post{Post: fmt.Sprintf("/%s/%s", service.Desc.FullName(), method.Desc.Name())},
+				Body:    "*",
+			})
+		}
+		for i, rule := range rules {
+			httpMethod, path, op := b.buildOperation(service, method, rule)
+			if httpMethod == "" {
+				continue
+			}
+			op.OperationId = service.GoName + "_" + method.GoName
+			if i > 0 {
+				op.OperationId += fmt.Sprintf("_%d", i)
+			}
+			op.Deprecated = op.Deprecated || serviceDeprecated
+			item, ok := doc.Paths[path]
+			if !ok {
+				item = &PathItem{}
+				doc.Paths[path] = item
+			}
+			(*item)[strings.ToLower(httpMethod)] = op
+		}
+	}
+	for name, s := range errorSchemas() {
+		if name == schemaProblem && !args.Problem {
+			continue
+		}
+		b.schemas[name] = s
+	}
+	doc.Components = &Components{Schemas: b.schemas}
+	return doc
+}
+
+func (b *builder) buildOperation(service *protogen.Service, m *protogen.Method, rule *annotations.HttpRule) (string, string, *Operation) {
+	var method, path string
+	switch pattern := rule.Pattern.(type) {
+	case *annotations.HttpRule_Get:
+		method, path = http.MethodGet, pattern.Get
+	case *annotations.HttpRule_Put:
+		method, path = http.MethodPut, pattern.Put
+	case *annotations.HttpRule_Post:
+		method, path = http.MethodPost, pattern.Post
+	case *annotations.HttpRule_Delete:
+		method, path = http.MethodDelete, pattern.Delete
+	case *annotations.HttpRule_Patch:
+		method, path = http.MethodPatch, pattern.Patch
+	case *annotations.HttpRule_Custom:
+		method, path = strings.ToUpper(pattern.Custom.Kind), pattern.Custom.Path
+	default:
+		return "", "", nil
+	}
+	body := rule.Body
+	switch {
+	case method == http.MethodGet:
+		body = ""
+	case method == http.MethodDelete && !args.AllowDeleteBody:
+		body = ""
+	}
+	path, vars := transformPath(path)
+
+	summary, description := splitComment(commentText(m.Comments.Leading, m.Comments.Trailing))
+	op := &Operation{
+		Tags:        []string{service.GoName},
+		Summary:     summary,
+		Description: description,
+		Deprecated:  m.Desc.Options().(*descriptorpb.MethodOptions).GetDeprecated(),
+		Responses:   make(map[string]*Response),
+	}
+	// path params
+	excludes := make(map[string]struct{})
+	for _, v := range vars {
+		excludes[v] = struct{}{}
+		f := findField(m.Input, v)
+		if f == nil {
+			fmt.Fprintf(os.Stderr, "\u001B[31mERROR\u001B[m: The corresponding field '%s' declaration in message could not be found in '%s'\n", v, path)
+			os.Exit(2)
+		}
+		op.Parameters = append(op.Parameters, &Parameter{
+			Name:        v,
+			In:          "path",
+			Description: commentText(f.Comments.Leading, f.Comments.Trailing),
+			Required:    true,
+			Schema:      b.fieldSchema(f),
+		})
+	}
+	// body
+	switch body {
+	case "":
+	case "*":
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]*MediaType{"application/json": {Schema: b.messageSchema(m.Input)}},
+		}
+	default:
+		f := findField(m.Input, body)
+		if f == nil {
+			fmt.Fprintf(os.Stderr, "\u001B[31mERROR\u001B[m: The body field '%s' declaration in message could not be found in '%s'\n", body, path)
+			os.Exit(2)
+		}
+		excludes[body] = struct{}{}
+		op.RequestBody = &RequestBody{
+			Description: commentText(f.Comments.Leading, f.Comments.Trailing),
+			Required:    true,
+			Content:     map[string]*MediaType{"application/json": {Schema: b.fieldSchema(f)}},
+		}
+	}
+	// query params, the fields neither in path nor in body.
+	if body != "*" {
+		op.Parameters = append(op.Parameters, b.queryParameters(m.Input, "", excludes, map[string]struct{}{})...)
+	}
+	// responses
+	reply := b.messageSchema(m.Output)
+	if rule.ResponseBody != "" && rule.ResponseBody != "*" {
+		if f := findField(m.Output, rule.ResponseBody); f != nil {
+			reply = b.fieldSchema(f)
+		}
+	}
+	op.Responses["200"] = &Response{
+		Description: "A successful response.",
+		Content:     map[string]*MediaType{"application/json": {Schema: reply}},
+	}
+	if args.Problem {
+		op.Responses["default"] = &Response{
+			Description: "An error response, the problem details.",
+			Content:     map[string]*MediaType{"application/problem+json": {Schema: refSchema(schemaProblem)}},
+		}
+	} else {
+		op.Responses["default"] = &Response{
+			Description: "An error response.",
+			Content:     map[string]*MediaType{"application/json": {Schema: refSchema(schemaError)}},
+		}
+	}
+	return method, path, op
+}
+
+// queryParameters returns the query params of the message fields, the message field is flattened
+// with the dotted name, like `a.b`, the map field and the recursive message are skipped.
+func (b *builder) queryParameters(m *protogen.Message, prefix string, excludes, visiting map[string]struct{}) []*Parameter {
+	if _, ok := visiting[string(m.Desc.FullName())]; ok {
+		return nil
+	}
+	visiting[string(m.Desc.FullName())] = struct{}{}
+	defer delete(visiting, string(m.Desc.FullName()))
+
+	var params []*Parameter
+	for _, f := range m.Fields {
+		name := prefix + string(f.Desc.Name())
+		if _, ok := excludes[name]; ok {
+			continue
+		}
+		if f.Desc.IsMap() {
+			continue
+		}
+		if f.Desc.Kind() == protoreflect.MessageKind || f.Desc.Kind() == protoreflect.GroupKind {
+			if _, scalar, ok := wellKnownSchema(f.Message); ok {
+				// the scalar well-known type is a query param as is, the others are skipped like the map.
+				if scalar {
+					params = append(params, &Parameter{
+						Name:        name,
+						In:          "query",
+						Description: commentText(f.Comments.Leading, f.Comments.Trailing),
+						Deprecated:  f.Desc.Options().(*descriptorpb.FieldOptions).GetDeprecated(),
+						Schema:      b.fieldSchema(f),
+					})
+				}
+				continue
+			}
+			if !f.Desc.IsList() {
+				params = append(params, b.queryParameters(f.Message, name+".", excludes, visiting)...)
+			}
+			continue
+		}
+		params = append(params, &Parameter{
+			Name:        name,
+			In:          "query",
+			Description: commentText(f.Comments.Leading, f.Comments.Trailing),
+			Deprecated:  f.Desc.Options().(*descriptorpb.FieldOptions).GetDeprecated(),
+			Schema:      b.fieldSchema(f),
+		})
+	}
+	return params
+}
+
+// messageSchema registers the message schema in components, returns the reference.
+func (b *builder) messageSchema(m *protogen.Message) *Schema {
+	name := string(m.Desc.FullName())
+	if _, ok := b.schemas[name]; ok {
+		return refSchema(name)
+	}
+	s := &Schema{
+		Type:        "object",
+		Description: commentText(m.Comments.Leading, m.Comments.Trailing),
+		Deprecated:  m.Desc.Options().(*descriptorpb.MessageOptions).GetDeprecated(),
+		Properties:  make(map[string]*Schema, len(m.Fields)),
+	}
+	// register before the fields, so the recursive message refers to itself.
+	b.schemas[name] = s
+	for _, f := range m.Fields {
+		s.Properties[string(f.Desc.Name())] = b.fieldSchema(f)
+	}
+	return refSchema(name)
+}
+
+// enumSchema registers the enum schema in components, returns the reference.
+// the enum is rendered as number, the labels are from the `protoc-gen-dyn-enum` annotations if enabled,
+// otherwise the comments.
+func (b *builder) enumSchema(e *protogen.Enum) *Schema {
+	name := string(e.Desc.FullName())
+	if _, ok := b.schemas[name]; ok {
+		return refSchema(name)
+	}
+	enumAnnotate, remainComments := protoenum.ParseDeriveEnum(e.Comments.Leading)
+	description := commentText(e.Comments.Leading, e.Comments.Trailing)
+	if enumAnnotate.Enabled {
+		description = strings.TrimSpace(strings.TrimSuffix(remainComments.LineString(), "\n"))
+	}
+	s := &Schema{
+		Type:   "integer",
+		Format: "int32",
+	}
+	lines := make([]string, 0, len(e.Values))
+	for _, v := range e.Values {
+		label := commentText(v.Comments.Leading, v.Comments.Trailing)
+		if enumAnnotate.Enabled {
+			valueAnnotate, remain := protoenum.ParseDeriveEnumValue(v.Comments.Leading)
+			label = valueAnnotate.Label
+			if label == "" {
+				label = strings.TrimSpace(strings.TrimSuffix(remain.LineString(), "\n"))
+			}
+		}
+		s.Enum = append(s.Enum, int32(v.Desc.Number()))
+		s.XEnumVarnames = append(s.XEnumVarnames, string(v.Desc.Name()))
+		s.XEnumDescriptions = append(s.XEnumDescriptions, label)
+		if label != "" {
+			lines = append(lines, fmt.Sprintf("%d: %s", v.Desc.Number(), label))
+		}
+	}
+	if len(lines) > 0 {
+		description = strings.TrimSpace(description + "\n\n" + strings.Join(lines, "\n"))
+	}
+	s.Description = description
+	b.schemas[name] = s
+	return refSchema(name)
+}
+
+// fieldSchema returns the schema of the field, the json name is the proto name, same as the carry encoding.
+func (b *builder) fieldSchema(f *protogen.Field) *Schema {
+	var s *Schema
+	switch {
+	case f.Desc.IsMap():
+		s = &Schema{Type: "object", AdditionalProperties: b.singularSchema(f.Message.Fields[1])}
+	case f.Desc.IsList():
+		s = &Schema{Type: "array", Items: b.singularSchema(f)}
+	default:
+		s = b.singularSchema(f)
+	}
+	s.Description = commentText(f.Comments.Leading, f.Comments.Trailing)
+	s.Deprecated = f.Desc.Options().(*descriptorpb.FieldOptions).GetDeprecated()
+	return s
+}
+
+func (b *builder) singularSchema(f *protogen.Field) *Schema {
+	switch f.Desc.Kind() {
+	case protoreflect.BoolKind:
+		return &Schema{Type: "boolean"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return &Schema{Type: "integer", Format: "int32"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return &Schema{Type: "integer", Format: "uint32"}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return &Schema{Type: "integer", Format: "int64"}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return &Schema{Type: "integer", Format: "uint64"}
+	case protoreflect.FloatKind:
+		return &Schema{Type: "number", Format: "float"}
+	case protoreflect.DoubleKind:
+		return &Schema{Type: "number", Format: "double"}
+	case protoreflect.StringKind:
+		return &Schema{Type: "string"}
+	case protoreflect.BytesKind:
+		return &Schema{Type: "string", ContentEncoding: "base64"}
+	case protoreflect.EnumKind:
+		return b.enumSchema(f.Enum)
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		if s, _, ok := wellKnownSchema(f.Message); ok {
+			return s
+		}
+		return b.messageSchema(f.Message)
+	default:
+		return &Schema{}
+	}
+}
+
+// wellKnownSchema returns the schema of the well-known type in the protojson form, if m is one,
+// scalar reports whether it is a scalar in json, which can be a query param.
+// See: https://protobuf.dev/programming-guides/proto3/#json
+func wellKnownSchema(m *protogen.Message) (s *Schema, scalar, ok bool) {
+	switch m.Desc.FullName() {
+	case "google.protobuf.Timestamp":
+		return &Schema{Type: "string", Format: "date-time"}, true, true
+	case "google.protobuf.Duration":
+		return &Schema{Type: "string", Pattern: `^-?[0-9]+(\.[0-9]{1,9})?s$`}, true, true
+	case "google.protobuf.FieldMask":
+		return &Schema{Type: "string", Description: "the comma-separated field paths."}, true, true
+	case "google.protobuf.DoubleValue":
+		return &Schema{Type: []string{"number", "null"}, Format: "double"}, true, true
+	case "google.protobuf.FloatValue":
+		return &Schema{Type: []string{"number", "null"}, Format: "float"}, true, true
+	case "google.protobuf.Int64Value":
+		return &Schema{Type: []string{"integer", "null"}, Format: "int64"}, true, true
+	case "google.protobuf.UInt64Value":
+		return &Schema{Type: []string{"integer", "null"}, Format: "uint64"}, true, true
+	case "google.protobuf.Int32Value":
+		return &Schema{Type: []string{"integer", "null"}, Format: "int32"}, true, true
+	case "google.protobuf.UInt32Value":
+		return &Schema{Type: []string{"integer", "null"}, Format: "uint32"}, true, true
+	case "google.protobuf.BoolValue":
+		return &Schema{Type: []string{"boolean", "null"}}, true, true
+	case "google.protobuf.StringValue":
+		return &Schema{Type: []string{"string", "null"}}, true, true
+	case "google.protobuf.BytesValue":
+		return &Schema{Type: []string{"string", "null"}, ContentEncoding: "base64"}, true, true
+	case "google.protobuf.Struct":
+		return &Schema{Type: "object", AdditionalProperties: &Schema{}}, false, true
+	case "google.protobuf.Value":
+		return &Schema{}, false, true
+	case "google.protobuf.ListValue":
+		return &Schema{Type: "array", Items: &Schema{}}, false, true
+	case "google.protobuf.Empty":
+		return &Schema{Type: "object"}, false, true
+	case "google.protobuf.Any":
+		return &Schema{
+			Type:                 "object",
+			Properties:           map[string]*Schema{"@type": {Type: "string", Description: "the type url of the message."}},
+			AdditionalProperties: &Schema{},
+			Required:             []string{"@type"},
+		}, false, true
+	default:
+		return nil, false, false
+	}
+}
+
+// findField finds the field by the dotted proto name path, like `a.b`.
+func findField(m *protogen.Message, path string) *protogen.Field {
+	var field *protogen.Field
+	for _, name := range strings.Split(path, ".") {
+		if m == nil {
+			return nil
+		}
+		field = nil
+		for _, f := range m.Fields {
+			if string(f.Desc.Name()) == name {
+				field = f
+				break
+			}
+		}
+		if field == nil {
+			return nil
+		}
+		m = field.Message
+	}
+	return field
+}
+
+// transformPath transform the path template into the OpenAPI path, returns the path vars.
+// {name=messages/*} --> {name}
+func transformPath(path string) (string, []string) {
+	var vars []string
+	paths := strings.Split(path, "/")
+	for i, p := range paths {
+		if strings.HasPrefix(p, "{") && strings.HasSuffix(p, "}") {
+			v, _, _ := strings.Cut(p[1:len(p)-1], "=")
+			vars = append(vars, v)
+			paths[i] = "{" + v + "}"
+		}
+	}
+	return strings.Join(paths, "/"), vars
+}
+
+// commentText returns the text of the comments, without the comment markers.
+func commentText(comments ...protogen.Comments) string {
+	lines := make([]string, 0, 4)
+	for _, c := range comments {
+		for _, line := range strings.Split(strings.TrimSuffix(string(c), "\n"), "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				lines = append(lines, line)
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// splitComment splits the comment into the summary(first line) and the description(the rest).
+func splitComment(s string) (string, string) {
+	summary, description, _ := strings.Cut(s, "\n")
+	return summary, strings.TrimSpace(description)
+}
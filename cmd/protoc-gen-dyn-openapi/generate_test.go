@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+var update = flag.Bool("update", false, "update the golden files in examples/gen")
+
+// testdata/bookstore.binpb is the descriptor set of examples/proto/bookstore, regenerate it by:
+//
+//	protoc -I ../../examples/proto -I <third_party> --include_imports --include_source_info \
+//		-o testdata/bookstore.binpb bookstore/bookstore.proto
+func Test_Generate_Golden(t *testing.T) {
+	b, err := os.ReadFile("testdata/bookstore.binpb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var set descriptorpb.FileDescriptorSet
+	if err = proto.Unmarshal(b, &set); err != nil {
+		t.Fatal(err)
+	}
+	gen, err := protogen.Options{}.New(&pluginpb.CodeGeneratorRequest{
+		FileToGenerate: []string{"bookstore/bookstore.proto"},
+		Parameter:      proto.String("paths=source_relative"),
+		ProtoFile:      set.GetFile(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = runProtoGen(gen); err != nil {
+		t.Fatal(err)
+	}
+	resp := gen.Response()
+	if resp.Error != nil {
+		t.Fatal(resp.GetError())
+	}
+	if len(resp.GetFile()) == 0 {
+		t.Fatal("no file generated")
+	}
+	for _, f := range resp.GetFile() {
+		golden := filepath.Join("../../examples/gen", f.GetName())
+		if *update {
+			if err = os.WriteFile(golden, []byte(f.GetContent()), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		want, err := os.ReadFile(golden)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(want, []byte(f.GetContent())) {
+			t.Errorf("%s mismatch the golden file, run `go test -update` to update it\ngot:\n%s", golden, f.GetContent())
+		}
+	}
+}
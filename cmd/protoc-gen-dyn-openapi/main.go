@@ -0,0 +1,41 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/things-go/dyn/cmd/internal/meta"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+var args = struct {
+	ShowVersion     bool
+	Omitempty       bool
+	AllowDeleteBody bool
+	ApiVersion      string
+	Problem         bool
+}{
+	ShowVersion:     false,
+	Omitempty:       true,
+	AllowDeleteBody: false,
+	ApiVersion:      "1.0.0",
+	Problem:         true,
+}
+
+func init() {
+	flag.BoolVar(&args.ShowVersion, "version", false, "print the version and exit")
+	flag.BoolVar(&args.Omitempty, "omitempty", true, "omit if google.api is empty")
+	flag.BoolVar(&args.AllowDeleteBody, "allow_delete_body", false, "allow delete body")
+	flag.StringVar(&args.ApiVersion, "api_version", "1.0.0", "the version of the api document, info.version")
+	flag.BoolVar(&args.Problem, "problem", true, "the error body is the problem details(carry.ProblemTransformer), otherwise the errorx.Error(carry.ErrorTransformer)")
+}
+
+func main() {
+	flag.Parse()
+	if args.ShowVersion {
+		fmt.Printf("protoc-gen-dyn-openapi %v\n", meta.Version)
+		return
+	}
+
+	protogen.Options{ParamFunc: flag.CommandLine.Set}.Run(runProtoGen)
+}
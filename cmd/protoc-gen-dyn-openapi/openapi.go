@@ -0,0 +1,159 @@
+package main
+
+// OpenAPI 3.1 document, only the parts used by the generator.
+// See: https://spec.openapis.org/oas/v3.1.0
+
+const openapiVersion = "3.1.0"
+
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       *Info                `json:"info"`
+	Tags       []*Tag               `json:"tags,omitempty"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components *Components          `json:"components,omitempty"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+type Tag struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// PathItem the operations of a path, http method(lower case) --> operation.
+type PathItem map[string]*Operation
+
+type Operation struct {
+	Tags        []string             `json:"tags,omitempty"`
+	Summary     string               `json:"summary,omitempty"`
+	Description string               `json:"description,omitempty"`
+	OperationId string               `json:"operationId"`
+	Deprecated  bool                 `json:"deprecated,omitempty"`
+	Parameters  []*Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Deprecated  bool    `json:"deprecated,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Description string                `json:"description,omitempty"`
+	Required    bool                  `json:"required,omitempty"`
+	Content     map[string]*MediaType `json:"content"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas,omitempty"`
+}
+
+type Schema struct {
+	Ref string `json:"$ref,omitempty"`
+	// Type the type name, or the type names like ["string", "null"] for the nullable one.
+	Type                 any                `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	ContentEncoding      string             `json:"contentEncoding,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Deprecated           bool               `json:"deprecated,omitempty"`
+	Default              any                `json:"default,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	XEnumVarnames        []string           `json:"x-enum-varnames,omitempty"`
+	XEnumDescriptions    []string           `json:"x-enum-descriptions,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+}
+
+func refSchema(name string) *Schema {
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// error body schema names
+const (
+	schemaProblem        = "carry.Problem"
+	schemaError          = "errorx.Error"
+	schemaErrorItem      = "errorx.ErrorItem"
+	schemaFieldViolation = "errorx.FieldViolation"
+)
+
+// errorSchemas the schemas of the error body rendered by carry,
+// keep in sync with `carry.Problem` and the json of `errorx.Error`.
+func errorSchemas() map[string]*Schema {
+	stringMap := &Schema{Type: "object", AdditionalProperties: &Schema{Type: "string"}}
+	violations := &Schema{Type: "array", Items: refSchema(schemaFieldViolation), Description: "the field violations of the invalid request."}
+	items := &Schema{Type: "array", Items: refSchema(schemaErrorItem), Description: "the sub errors of the batch operation."}
+	return map[string]*Schema{
+		schemaProblem: {
+			Type:        "object",
+			Description: "the problem details for HTTP APIs, see RFC 9457, the error metadata and the custom extensions are the extension members of any type.",
+			Properties: map[string]*Schema{
+				"type":       {Type: "string", Format: "uri-reference", Default: "about:blank"},
+				"title":      {Type: "string"},
+				"status":     {Type: "integer", Format: "int32"},
+				"detail":     {Type: "string"},
+				"instance":   {Type: "string"},
+				"code":       {Type: "integer", Format: "int32", Description: "the business error code."},
+				"reason":     {Type: "string", Description: "the machine-readable reason of the error, UPPER_SNAKE_CASE."},
+				"domain":     {Type: "string"},
+				"request_id": {Type: "string", Description: "the request id, quote it in the support ticket."},
+				"violations": violations,
+				"errors":     items,
+			},
+		},
+		schemaError: {
+			Type: "object",
+			Properties: map[string]*Schema{
+				"code":       {Type: "integer", Format: "int32", Description: "the business error code."},
+				"reason":     {Type: "string", Description: "the machine-readable reason of the error, UPPER_SNAKE_CASE."},
+				"domain":     {Type: "string"},
+				"message":    {Type: "string"},
+				"metadata":   stringMap,
+				"violations": violations,
+				"errors":     items,
+				"request_id": {Type: "string", Description: "the request id, quote it in the support ticket."},
+			},
+			Required: []string{"code", "message"},
+		},
+		schemaErrorItem: {
+			Type: "object",
+			Properties: map[string]*Schema{
+				"index": {Type: "integer", Format: "int32", Description: "the index of the item in the batch."},
+				"key":   {Type: "string", Description: "the key of the item in the batch."},
+				"error": refSchema(schemaError),
+			},
+			Required: []string{"error"},
+		},
+		schemaFieldViolation: {
+			Type: "object",
+			Properties: map[string]*Schema{
+				"field":   {Type: "string"},
+				"tag":     {Type: "string"},
+				"param":   {Type: "string"},
+				"message": {Type: "string"},
+			},
+			Required: []string{"field", "message"},
+		},
+	}
+}
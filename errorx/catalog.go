@@ -0,0 +1,240 @@
+package errorx
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"maps"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/things-go/encoding/codec"
+	"github.com/things-go/encoding/json"
+	"github.com/things-go/encoding/toml"
+	"github.com/things-go/encoding/yaml"
+
+	"github.com/things-go/dyn/transport"
+)
+
+// BuiltinLocales the builtin translations of the errors in `types.go`,
+// load it with `Catalog.LoadFS(BuiltinLocales, "locales/*.json")`.
+//
+//go:embed locales/*.json
+var BuiltinLocales embed.FS
+
+// catalogCodecs file extension --> codec used to decode catalog file.
+var catalogCodecs = map[string]codec.Marshaler{
+	".json": &json.Codec{},
+	".yaml": &yaml.Codec{},
+	".yml":  &yaml.Codec{},
+	".toml": &toml.Codec{},
+}
+
+// Catalog is a message catalog keyed by error code (and optional reason)
+// with per-locale translations.
+type Catalog struct {
+	mu       sync.RWMutex
+	fallback string
+	// locale --> key(code or reason) --> message
+	messages map[string]map[string]string
+}
+
+// NewCatalog new message catalog,
+// fallback is the locale used when no locale in `Accept-Language` matches.
+func NewCatalog(fallback string) *Catalog {
+	return &Catalog{
+		fallback: normalizeLocale(fallback),
+		messages: make(map[string]map[string]string),
+	}
+}
+
+// Add adds the translation message of the key for the locale,
+// key is the error code(like "1001") or the reason.
+func (c *Catalog) Add(locale, key, message string) {
+	c.AddMessages(locale, map[string]string{key: message})
+}
+
+// AddMessages adds the translation messages for the locale,
+// key is the error code(like "1001") or the reason.
+func (c *Catalog) AddMessages(locale string, messages map[string]string) {
+	locale = normalizeLocale(locale)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	mp, ok := c.messages[locale]
+	if !ok {
+		mp = make(map[string]string, len(messages))
+		c.messages[locale] = mp
+	}
+	maps.Copy(mp, messages)
+}
+
+// LoadFS loads the catalog files matched the patterns from the file system,
+// the locale is the file name without extension, like `en.json`, `zh-CN.yaml`.
+// supported format: json, yaml, toml.
+// the content of file is a flat map of key(code or reason) --> message.
+func (c *Catalog) LoadFS(fsys fs.FS, patterns ...string) error {
+	for _, pattern := range patterns {
+		filenames, err := fs.Glob(fsys, pattern)
+		if err != nil {
+			return err
+		}
+		for _, filename := range filenames {
+			ext := path.Ext(filename)
+			cc, ok := catalogCodecs[strings.ToLower(ext)]
+			if !ok {
+				return fmt.Errorf("errorx: unsupported catalog file(%s)", filename)
+			}
+			data, err := fs.ReadFile(fsys, filename)
+			if err != nil {
+				return err
+			}
+			messages := make(map[string]string)
+			if err = cc.Unmarshal(data, &messages); err != nil {
+				return fmt.Errorf("errorx: decode catalog file(%s) failed, %w", filename, err)
+			}
+			c.AddMessages(strings.TrimSuffix(path.Base(filename), ext), messages)
+		}
+	}
+	return nil
+}
+
+// Lookup returns the translation message of the code and reason,
+// the locale is selected from the `Accept-Language`.
+// reason take precedence over code if it is not empty.
+func (c *Catalog) Lookup(acceptLanguage string, code int32, reason string) (string, bool) {
+	msg, _, ok := c.lookupLanguage(acceptLanguage, code, reason)
+	return msg, ok
+}
+
+// lookupLanguage like Lookup, byReason reports whether the message is keyed by the reason.
+func (c *Catalog) lookupLanguage(acceptLanguage string, code int32, reason string) (msg string, byReason, ok bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, locale := range parseAcceptLanguage(acceptLanguage) {
+		if msg, byReason, ok = c.lookup(locale, code, reason); ok {
+			return msg, byReason, true
+		}
+		if base, _, found := strings.Cut(locale, "-"); found {
+			if msg, byReason, ok = c.lookup(base, code, reason); ok {
+				return msg, byReason, true
+			}
+		}
+	}
+	return c.lookup(c.fallback, code, reason)
+}
+
+func (c *Catalog) lookup(locale string, code int32, reason string) (string, bool, bool) {
+	mp, ok := c.messages[locale]
+	if !ok {
+		return "", false, false
+	}
+	if reason != "" {
+		if msg, ok := mp[reason]; ok {
+			return msg, true, true
+		}
+	}
+	msg, ok := mp[strconv.FormatInt(int64(code), 10)]
+	return msg, false, ok
+}
+
+// isDefaultMessage reports whether the message is still the default text of the code,
+// that is the message registered in `DefaultRegistry` or any translation of the code in the catalog.
+func (c *Catalog) isDefaultMessage(code int32, message string) bool {
+	if entry, ok := DefaultRegistry.Lookup(code); ok && entry.Message == message {
+		return true
+	}
+	key := strconv.FormatInt(int64(code), 10)
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, mp := range c.messages {
+		if msg, ok := mp[key]; ok && msg == message {
+			return true
+		}
+	}
+	return false
+}
+
+// Localize returns the error with the translation message,
+// the locale is selected from the request `Accept-Language` via the `transport.Transporter` in context.
+// err is parsed via `Parse`, if no translation found, return the parsed error.
+// the translation keyed by the reason always applies, but the one keyed by the code applies only when
+// the message is still the default text of the code, so the specific message of the caller is kept,
+// like `NewBadRequest(WithMessage("name empty"))`.
+func (c *Catalog) Localize(ctx context.Context, err error) *Error {
+	e := Parse(err)
+	if e == nil {
+		return nil
+	}
+	acceptLanguage := ""
+	if tr, ok := transport.FromTransporter(ctx); ok {
+		acceptLanguage = tr.RequestHeader().Get("Accept-Language")
+	}
+	msg, byReason, ok := c.lookupLanguage(acceptLanguage, e.code, e.reason)
+	if !ok || msg == e.message || (!byReason && !c.isDefaultMessage(e.code, e.message)) {
+		return e
+	}
+	return e.clone().TakeOption(WithMessage(msg))
+}
+
+// clone returns a shallow copy of e, but the metadata is copied.
+func (e *Error) clone() *Error {
+	ce := *e
+	ce.metadata = maps.Clone(e.metadata)
+	ce.sensitive = maps.Clone(e.sensitive)
+	return &ce
+}
+
+// normalizeLocale normalize the locale, like zh_CN --> zh-cn
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
+
+// parseAcceptLanguage parse the `Accept-Language`, returns the locales sorted by quality.
+// like: "zh-CN,zh;q=0.9,en;q=0.8" --> [zh-cn zh en]
+func parseAcceptLanguage(acceptLanguage string) []string {
+	type weighted struct {
+		locale  string
+		quality float64
+	}
+
+	if acceptLanguage == "" {
+		return nil
+	}
+	values := strings.Split(acceptLanguage, ",")
+	ws := make([]weighted, 0, len(values))
+	for _, value := range values {
+		locale, params, _ := strings.Cut(value, ";")
+		locale = normalizeLocale(locale)
+		if locale == "" || locale == "*" {
+			continue
+		}
+		quality := 1.0
+		if q, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			if f, err := strconv.ParseFloat(q, 64); err == nil {
+				quality = f
+			}
+		}
+		if quality > 0 {
+			ws = append(ws, weighted{locale, quality})
+		}
+	}
+	slices.SortStableFunc(ws, func(a, b weighted) int {
+		switch {
+		case a.quality > b.quality:
+			return -1
+		case a.quality < b.quality:
+			return 1
+		default:
+			return 0
+		}
+	})
+	locales := make([]string, 0, len(ws))
+	for _, w := range ws {
+		locales = append(locales, w.locale)
+	}
+	return locales
+}
@@ -0,0 +1,93 @@
+package errorx_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/things-go/dyn/errorx"
+)
+
+func Test_Catalog(t *testing.T) {
+	c := errorx.NewCatalog("zh")
+	err := c.LoadFS(errorx.BuiltinLocales, "locales/*.json")
+	require.NoError(t, err)
+	err = c.LoadFS(fstest.MapFS{
+		"en-US.yaml": {Data: []byte("\"1001\": user does not exist\n")},
+		"zh_CN.toml": {Data: []byte("1001 = \"用户不存在\"\n")},
+	}, "*.yaml", "*.toml")
+	require.NoError(t, err)
+	c.Add("en", "USER_NOT_EXIST", "the user does not exist")
+	c.Add("zh", "ORDER_NOT_EXIST", "订单不存在")
+
+	t.Run("lookup", func(t *testing.T) {
+		msg, ok := c.Lookup("en-US,en;q=0.9", 404, "")
+		require.True(t, ok)
+		require.Equal(t, msg, "Not found, the resource does not exist")
+
+		msg, ok = c.Lookup("zh;q=0.5,en-US", 1001, "")
+		require.True(t, ok)
+		require.Equal(t, msg, "user does not exist")
+
+		msg, ok = c.Lookup("zh-CN", 1001, "")
+		require.True(t, ok)
+		require.Equal(t, msg, "用户不存在")
+
+		msg, ok = c.Lookup("en", 1001, "USER_NOT_EXIST")
+		require.True(t, ok)
+		require.Equal(t, msg, "the user does not exist")
+	})
+	t.Run("fallback", func(t *testing.T) {
+		msg, ok := c.Lookup("fr", 400, "")
+		require.True(t, ok)
+		require.Equal(t, msg, "请求参数错误")
+
+		_, ok = c.Lookup("fr", 1002, "")
+		require.False(t, ok)
+	})
+	t.Run("localize", func(t *testing.T) {
+		require.Nil(t, c.Localize(context.Background(), nil))
+
+		err := errorx.New(1002, "未知")
+		require.Equal(t, c.Localize(context.Background(), err), err)
+
+		gotErr := c.Localize(context.Background(), errors.New("内部错误"))
+		require.Equal(t, gotErr.Code(), int32(500))
+		require.Equal(t, gotErr.Message(), "服务器错误")
+
+		gotErr = c.Localize(context.Background(), errorx.New(1003, "order not exist", errorx.WithReason("ORDER_NOT_EXIST")))
+		require.Equal(t, gotErr.Message(), "订单不存在")
+
+		gotErr = c.Localize(context.Background(), errorx.New(400, "Bad request"))
+		require.Equal(t, gotErr.Message(), "请求参数错误")
+	})
+	t.Run("keep custom message", func(t *testing.T) {
+		gotErr := c.Localize(context.Background(), errorx.NewBadRequest(errorx.WithMessage("name empty")))
+		require.Equal(t, gotErr.Code(), int32(400))
+		require.Equal(t, gotErr.Message(), "name empty")
+
+		gotErr = c.Localize(context.Background(), errorx.NewConflict(errorx.WithMessage("order already paid")))
+		require.Equal(t, gotErr.Message(), "order already paid")
+
+		gotErr = c.Localize(context.Background(), errorx.NewBadRequest())
+		require.Equal(t, gotErr.Message(), "请求参数错误")
+	})
+	t.Run("registered default message", func(t *testing.T) {
+		c := errorx.NewCatalog("en")
+		c.AddMessages("en", map[string]string{"1101": "user does not exist"})
+		errorx.MustRegister("errorx_test.Catalog", errorx.New(1101, "用户不存在"))
+
+		gotErr := c.Localize(context.Background(), errorx.New(1101, "用户不存在"))
+		require.Equal(t, gotErr.Message(), "user does not exist")
+
+		gotErr = c.Localize(context.Background(), errorx.New(1101, "用户已被禁用"))
+		require.Equal(t, gotErr.Message(), "用户已被禁用")
+	})
+	t.Run("unsupported", func(t *testing.T) {
+		err := c.LoadFS(fstest.MapFS{"en.ini": {Data: []byte("")}}, "*.ini")
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,149 @@
+package errorx
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/things-go/dyn/transport"
+)
+
+// FieldViolation describes a single bad request field.
+type FieldViolation struct {
+	// Field path, like `user.name`, `items[0].name`, use the json/proto names.
+	Field string `json:"field"`
+	// Tag the failed validation tag, like `required`.
+	Tag string `json:"tag,omitempty"`
+	// Param the param of the validation tag, like `10` of `max=10`.
+	Param string `json:"param,omitempty"`
+	// Message the (translated) description of the violation.
+	Message string `json:"message"`
+}
+
+// WithFieldViolations add field violations to the error.
+func WithFieldViolations(vs ...*FieldViolation) Option {
+	return func(e *Error) {
+		e.fieldViolations = append(e.fieldViolations, vs...)
+	}
+}
+
+// WithFieldViolations add field violations to the error.
+func (e *Error) WithFieldViolations(vs ...*FieldViolation) *Error {
+	return e.TakeOption(WithFieldViolations(vs...))
+}
+
+// FieldViolations get the field violations.
+func (e *Error) FieldViolations() []*FieldViolation {
+	if e == nil {
+		return nil
+	}
+	return e.fieldViolations
+}
+
+// intoBadRequest convert field violations into `errdetails.BadRequest`,
+// the tag and param are not carried.
+func intoBadRequest(vs []*FieldViolation) *errdetails.BadRequest {
+	fvs := make([]*errdetails.BadRequest_FieldViolation, 0, len(vs))
+	for _, v := range vs {
+		fvs = append(fvs, &errdetails.BadRequest_FieldViolation{
+			Field:       v.Field,
+			Description: v.Message,
+		})
+	}
+	return &errdetails.BadRequest{FieldViolations: fvs}
+}
+
+// fromBadRequest convert `errdetails.BadRequest` into field violations.
+func fromBadRequest(br *errdetails.BadRequest) []*FieldViolation {
+	vs := make([]*FieldViolation, 0, len(br.GetFieldViolations()))
+	for _, v := range br.GetFieldViolations() {
+		vs = append(vs, &FieldViolation{
+			Field:   v.GetField(),
+			Message: v.GetDescription(),
+		})
+	}
+	return slices.Clip(vs)
+}
+
+// WithRetry mark the error as retryable, the client should retry after the delay,
+// delay <= 0 means no specific delay.
+func WithRetry(delay time.Duration) Option {
+	return func(e *Error) {
+		e.retryable = true
+		e.retryDelay = max(delay, 0)
+	}
+}
+
+// WithRetry mark the error as retryable, the client should retry after the delay,
+// delay <= 0 means no specific delay.
+func (e *Error) WithRetry(delay time.Duration) *Error {
+	return e.TakeOption(WithRetry(delay))
+}
+
+// Retryable reports whether the error is retryable.
+func (e *Error) Retryable() bool {
+	return e != nil && e.retryable
+}
+
+// RetryDelay get the retry delay, 0 means no specific delay.
+func (e *Error) RetryDelay() time.Duration {
+	if e == nil {
+		return 0
+	}
+	return e.retryDelay
+}
+
+// IsRetryable reports whether the first `Error` in err's tree is retryable, and returns the retry delay.
+func IsRetryable(err error) (time.Duration, bool) {
+	if te := new(Error); errors.As(err, &te) && te.Retryable() {
+		return te.retryDelay, true
+	}
+	return 0, false
+}
+
+// intoRetryInfo convert the retry delay into `errdetails.RetryInfo`.
+func intoRetryInfo(delay time.Duration) *errdetails.RetryInfo {
+	ri := &errdetails.RetryInfo{}
+	if delay > 0 {
+		ri.RetryDelay = durationpb.New(delay)
+	}
+	return ri
+}
+
+// WithRequestId set the request id, it is rendered to the client so it can be quoted in the support ticket.
+func WithRequestId(id string) Option {
+	return func(e *Error) {
+		e.requestId = id
+	}
+}
+
+// WithRequestId set the request id, it is rendered to the client so it can be quoted in the support ticket.
+func (e *Error) WithRequestId(id string) *Error {
+	return e.TakeOption(WithRequestId(id))
+}
+
+// RequestId get the request id.
+func (e *Error) RequestId() string {
+	if e == nil {
+		return ""
+	}
+	return e.requestId
+}
+
+// WithContextRequestId returns a copy of the error with the request id in context, see `transport.FromRequestId`.
+// the error itself is returned if the request id is already set or no request id in context,
+// unlike the `With*` methods, the error is not modified, so it is safe for the shared error.
+func (e *Error) WithContextRequestId(ctx context.Context) *Error {
+	if e == nil || e.requestId != "" {
+		return e
+	}
+	id, ok := transport.FromRequestId(ctx)
+	if !ok || id == "" {
+		return e
+	}
+	return e.clone().TakeOption(WithRequestId(id))
+}
@@ -1,25 +1,59 @@
 package errorx
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+
+	"github.com/things-go/dyn/pkg/deploy"
 )
 
+// metadataKeyCode the key of the business code stored in `errdetails.ErrorInfo` metadata,
+// so the original code can be restored from a gRPC status.
+const metadataKeyCode = "errorx-code"
+
+// metadataKeyStatus the key of the http status stored in `errdetails.ErrorInfo` metadata,
+// so the original http status can be restored from a gRPC status.
+const metadataKeyStatus = "errorx-status"
+
 var _ error = (*Error)(nil)
 
 // Error define the error type
 type Error struct {
-	code     int32
+	code     int32      // business code, it may be an errno or an http status.
+	status   int        // http status, if zero, derived from grpcCode or code.
+	grpcCode codes.Code // gRPC code, if OK, derived from http status.
 	message  string
+	reason   string // machine-readable reason, UPPER_SNAKE, like USER_NOT_EXIST.
+	domain   string // the logical grouping to which the reason belongs, like the service name.
 	cause    error
 	metadata map[string]string
+	// sensitive the keys of metadata which are only available to the server side.
+	sensitive map[string]struct{}
+	stack     stack // stack trace where the error is created, if captured.
+	// fieldViolations the bad request field violations.
+	fieldViolations []*FieldViolation
+	// retryable whether the client should retry, retryDelay is the delay before retrying.
+	retryable  bool
+	retryDelay time.Duration
+	// items the sub errors of the aggregate error, see `MultiError`.
+	items []*ErrorItem
+	// requestId the request id of the request which the error is returned to.
+	requestId string
 }
 
 // Error implement `Error() string` interface.
+// NOTE: it contains the cause which may be internal details,
+// use `Message` or `Public` for the client side.
 func (e *Error) Error() string {
 	if e == nil {
 		return "<nil>"
@@ -38,6 +72,51 @@ func (e *Error) Code() int32 {
 	return e.code
 }
 
+// HTTPStatus get the http status.
+// if not set explicitly, it is derived from gRPC code,
+// otherwise the code is used as the http status if it is a valid http status,
+// else 500.
+func (e *Error) HTTPStatus() int {
+	return e.HTTPStatusWith(DefaultConverter)
+}
+
+// HTTPStatusWith same as HTTPStatus, but the gRPC code is converted with the converter,
+// nil means DefaultConverter.
+func (e *Error) HTTPStatusWith(c Converter) int {
+	if e == nil {
+		return http.StatusOK
+	}
+	switch {
+	case e.status != 0:
+		return e.status
+	case e.grpcCode != codes.OK:
+		return converterOrDefault(c).FromGRPCCode(e.grpcCode)
+	case e.code >= 100 && e.code <= 599:
+		return int(e.code)
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// GRPCCode get the gRPC code.
+// if not set explicitly, it is converted from the http status.
+func (e *Error) GRPCCode() codes.Code {
+	return e.GRPCCodeWith(DefaultConverter)
+}
+
+// GRPCCodeWith same as GRPCCode, but the http status is converted with the converter,
+// nil means DefaultConverter.
+func (e *Error) GRPCCodeWith(c Converter) codes.Code {
+	if e == nil {
+		return codes.OK
+	}
+	if e.grpcCode != codes.OK {
+		return e.grpcCode
+	}
+	c = converterOrDefault(c)
+	return c.ToGRPCCode(e.HTTPStatusWith(c))
+}
+
 // Message get the message
 func (e *Error) Message() string {
 	if e == nil {
@@ -46,6 +125,22 @@ func (e *Error) Message() string {
 	return e.message
 }
 
+// Reason get the machine-readable reason, like USER_NOT_EXIST.
+func (e *Error) Reason() string {
+	if e == nil {
+		return ""
+	}
+	return e.reason
+}
+
+// Domain get the logical grouping to which the reason belongs.
+func (e *Error) Domain() string {
+	if e == nil {
+		return ""
+	}
+	return e.domain
+}
+
 // Metadata get metadata
 func (e *Error) Metadata() map[string]string {
 	if e == nil {
@@ -62,17 +157,48 @@ func (e *Error) Unwrap() error {
 	return e.cause
 }
 
+// Is implement `errors.Is`, it reports whether the target is an `Error` with the same code,
+// and the same reason and domain if the target's are set,
+// so `errors.Is(err, errno.ErrUserNotExist())` works through wrapping.
+func (e *Error) Is(target error) bool {
+	te, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+	if e == nil || te == nil {
+		return e == te
+	}
+	return e.code == te.code &&
+		(te.reason == "" || e.reason == te.reason) &&
+		(te.domain == "" || e.domain == te.domain)
+}
+
 type Option func(*Error)
 
 // New new Error
 func New(code int32, message string, opts ...Option) *Error {
-	e := &Error{code: code, message: message}
+	e := &Error{code: code, message: message, stack: autoCallers()}
 	return e.TakeOption(opts...)
 }
 
 // Newf new Error
 func Newf(code int32, format string, args ...any) *Error {
-	return &Error{code: code, message: fmt.Sprintf(format, args...)}
+	return &Error{code: code, message: fmt.Sprintf(format, args...), stack: autoCallers()}
+}
+
+// NewWithStatus new Error with the http status,
+// the business code is separated from the http status.
+func NewWithStatus(status int, code int32, message string, opts ...Option) *Error {
+	e := &Error{code: code, status: status, message: message, stack: autoCallers()}
+	return e.TakeOption(opts...)
+}
+
+// autoCallers captures the stack automatically when `deploy.IsTesting()`.
+func autoCallers() stack {
+	if deploy.IsTesting() {
+		return callers()
+	}
+	return nil
 }
 
 // TakeOption custom options
@@ -111,11 +237,31 @@ func (e *Error) WithErrorf(format string, args ...any) *Error {
 	return e.TakeOption(WithErrorf(format, args...))
 }
 
+// WithReason set the machine-readable reason
+func (e *Error) WithReason(reason string) *Error {
+	return e.TakeOption(WithReason(reason))
+}
+
+// WithDomain set the domain of the reason
+func (e *Error) WithDomain(domain string) *Error {
+	return e.TakeOption(WithDomain(domain))
+}
+
 // WithMetadata add metadata to the error
 func (e *Error) WithMetadata(k, v string) *Error {
 	return e.TakeOption(WithMetadata(k, v))
 }
 
+// WithStatus set the http status
+func (e *Error) WithStatus(status int) *Error {
+	return e.TakeOption(WithStatus(status))
+}
+
+// WithGRPCCode set the gRPC code
+func (e *Error) WithGRPCCode(code codes.Code) *Error {
+	return e.TakeOption(WithGRPCCode(code))
+}
+
 // WithMessage modifies the message
 func WithMessage(s string) Option {
 	return func(e *Error) {
@@ -147,6 +293,20 @@ func WithErrorf(format string, args ...any) Option {
 	return WithCause(fmt.Errorf(format, args...))
 }
 
+// WithReason set the machine-readable reason, it should be UPPER_SNAKE, like USER_NOT_EXIST.
+func WithReason(reason string) Option {
+	return func(e *Error) {
+		e.reason = reason
+	}
+}
+
+// WithDomain set the domain of the reason, like the service name `user.example.com`.
+func WithDomain(domain string) Option {
+	return func(e *Error) {
+		e.domain = domain
+	}
+}
+
 // WithMetadata add metadata to the error
 func WithMetadata(k, v string) Option {
 	return func(e *Error) {
@@ -159,6 +319,20 @@ func WithMetadata(k, v string) Option {
 	}
 }
 
+// WithStatus set the http status
+func WithStatus(status int) Option {
+	return func(e *Error) {
+		e.status = status
+	}
+}
+
+// WithGRPCCode set the gRPC code
+func WithGRPCCode(code codes.Code) Option {
+	return func(e *Error) {
+		e.grpcCode = code
+	}
+}
+
 // Parse parser error is `Error`, if not `Error`, new `Error` with code 500 and warp the err.
 // err == nil: return nil
 // err is not Error: return NewInternalServer
@@ -176,6 +350,14 @@ func Parse(err error) *Error {
 	return NewInternalServer(WithCause(err))
 }
 
+// MetadataFrom returns the metadata of the first `Error` in err's tree via `errors.As`.
+func MetadataFrom(err error) (map[string]string, bool) {
+	if te := new(Error); errors.As(err, &te) && te != nil {
+		return te.metadata, true
+	}
+	return nil, false
+}
+
 // EqualCode return true if error underlying code equal target code.
 // err == nil: code = 200
 // err is not Error: code = 500
@@ -197,12 +379,161 @@ func EqualCode(err error, targetCode int32) bool {
 	return http.StatusInternalServerError == targetCode
 }
 
+// MarshalJSON implement json.Marshaler, the error is rendered as
+//
+//	{"code":1001,"reason":"USER_NOT_EXIST","domain":"user","message":"用户不存在","metadata":{},"violations":[],"errors":[],"request_id":""}
+//
+// the error is exposed via `Public`, the cause and stack trace are never rendered.
+func (x *Error) MarshalJSON() ([]byte, error) {
+	if x == nil {
+		return []byte("null"), nil
+	}
+	x = x.Public()
+	return json.Marshal(struct {
+		Code       int32             `json:"code"`
+		Reason     string            `json:"reason,omitempty"`
+		Domain     string            `json:"domain,omitempty"`
+		Message    string            `json:"message"`
+		Metadata   map[string]string `json:"metadata,omitempty"`
+		Violations []*FieldViolation `json:"violations,omitempty"`
+		Errors     []*ErrorItem      `json:"errors,omitempty"`
+		RequestId  string            `json:"request_id,omitempty"`
+	}{
+		Code:       x.code,
+		Reason:     x.reason,
+		Domain:     x.domain,
+		Message:    x.message,
+		Metadata:   x.metadata,
+		Violations: x.fieldViolations,
+		Errors:     x.items,
+		RequestId:  x.requestId,
+	})
+}
+
 // GRPCStatus returns the Status represented by se.
+// the error is exposed via `Public`, so the cause, stack trace and sensitive metadata
+// are carried by `errdetails.DebugInfo` only when `deploy.IsTesting()`, otherwise they are stripped.
 func (x *Error) GRPCStatus() *status.Status {
-	s, _ := status.New(ToGRPCCode(int(x.code)), x.message).
-		WithDetails(&errdetails.ErrorInfo{
-			Reason:   x.Error(),
-			Metadata: x.metadata,
-		})
+	return x.GRPCStatusWith(DefaultConverter)
+}
+
+// GRPCStatusWith same as GRPCStatus, but the codes are converted with the converter,
+// nil means DefaultConverter.
+func (x *Error) GRPCStatusWith(c Converter) *status.Status {
+	x = x.Public()
+	md := make(map[string]string, len(x.metadata)+2)
+	for k, v := range x.metadata {
+		md[k] = v
+	}
+	md[metadataKeyCode] = strconv.FormatInt(int64(x.code), 10)
+	md[metadataKeyStatus] = strconv.Itoa(x.HTTPStatusWith(c))
+	details := []protoadapt.MessageV1{
+		&errdetails.ErrorInfo{
+			Reason:   x.reason,
+			Domain:   x.domain,
+			Metadata: md,
+		},
+	}
+	if len(x.fieldViolations) > 0 {
+		details = append(details, intoBadRequest(x.fieldViolations))
+	}
+	if x.retryable {
+		details = append(details, intoRetryInfo(x.retryDelay))
+	}
+	if x.requestId != "" {
+		details = append(details, &errdetails.RequestInfo{RequestId: x.requestId})
+	}
+	for _, item := range x.items {
+		details = append(details, item.intoStatus(c))
+	}
+	if di := x.intoDebugInfo(); di != nil {
+		details = append(details, di)
+	}
+	s, _ := status.New(x.GRPCCodeWith(c), x.message).WithDetails(details...)
 	return s
 }
+
+// FromGRPCStatus rebuild the `Error` from the gRPC status, it is the inverse of `GRPCStatus`.
+// s == nil or code is OK: return nil
+// s has `errdetails.ErrorInfo` detail: restore the original code, http status, reason, domain and metadata.
+// otherwise: the code and http status are converted from the gRPC code.
+func FromGRPCStatus(s *status.Status) *Error {
+	return FromGRPCStatusWith(s, DefaultConverter)
+}
+
+// FromGRPCStatusWith same as FromGRPCStatus, but the gRPC code is converted with the converter,
+// nil means DefaultConverter.
+func FromGRPCStatusWith(s *status.Status, c Converter) *Error {
+	if s == nil || s.Code() == codes.OK {
+		return nil
+	}
+	httpStatus := converterOrDefault(c).FromGRPCCode(s.Code())
+	e := &Error{
+		code:     int32(httpStatus),
+		status:   httpStatus,
+		grpcCode: s.Code(),
+		message:  s.Message(),
+	}
+	for _, detail := range s.Details() {
+		switch d := detail.(type) {
+		case *errdetails.ErrorInfo:
+			e.reason = d.Reason
+			e.domain = d.Domain
+			for k, v := range d.Metadata {
+				switch k {
+				case metadataKeyCode:
+					if code, err := strconv.ParseInt(v, 10, 32); err == nil {
+						e.code = int32(code)
+					}
+					continue
+				case metadataKeyStatus:
+					if httpStatus, err := strconv.Atoi(v); err == nil {
+						e.status = httpStatus
+					}
+					continue
+				}
+				WithMetadata(k, v)(e)
+			}
+		case *errdetails.BadRequest:
+			e.fieldViolations = fromBadRequest(d)
+		case *errdetails.RetryInfo:
+			e.retryable = true
+			e.retryDelay = d.GetRetryDelay().AsDuration()
+		case *errdetails.RequestInfo:
+			e.requestId = d.GetRequestId()
+		case *spb.Status:
+			if item := fromStatus(d, c); item != nil {
+				e.items = append(e.items, item)
+			}
+		case *errdetails.DebugInfo:
+			if d.Detail != "" {
+				e.cause = errors.New(d.Detail)
+			}
+		}
+	}
+	return e
+}
+
+// FromError try to convert an error to `Error`, it supports gRPC status error.
+// err == nil: return nil
+// err is Error: same as `Parse`
+// err is gRPC status error: return FromGRPCStatus
+// otherwise: return NewInternalServer
+func FromError(err error) *Error {
+	return FromErrorWith(err, DefaultConverter)
+}
+
+// FromErrorWith same as FromError, but the gRPC code is converted with the converter,
+// nil means DefaultConverter.
+func FromErrorWith(err error, c Converter) *Error {
+	if err == nil {
+		return nil
+	}
+	if te := new(Error); errors.As(err, &te) {
+		return te
+	}
+	if s, ok := status.FromError(err); ok {
+		return FromGRPCStatusWith(s, c)
+	}
+	return NewInternalServer(WithCause(err))
+}
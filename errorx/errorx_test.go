@@ -1,11 +1,19 @@
 package errorx_test
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
 	"github.com/things-go/dyn/errorx"
+	"github.com/things-go/dyn/transport"
 )
 
 func Test_NilError(t *testing.T) {
@@ -127,3 +135,199 @@ func Test_Error_EqualCode(t *testing.T) {
 		require.True(t, errorx.EqualCode(err1, 400))
 	})
 }
+
+func Test_GRPCStatus(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		err := errorx.New(1001, "用户不存在", errorx.WithMetadata("k1", "v1"))
+
+		gotErr := errorx.FromGRPCStatus(err.GRPCStatus())
+		require.Equal(t, gotErr.Code(), int32(1001))
+		require.Equal(t, gotErr.Message(), "用户不存在")
+		require.Equal(t, gotErr.Metadata(), map[string]string{"k1": "v1"})
+		require.True(t, errorx.EqualCode(gotErr, 1001))
+		require.Equal(t, err.Metadata(), map[string]string{"k1": "v1"})
+	})
+	t.Run("without error info", func(t *testing.T) {
+		gotErr := errorx.FromGRPCStatus(status.New(codes.NotFound, "not found"))
+		require.Equal(t, gotErr.Code(), int32(404))
+		require.Equal(t, gotErr.Message(), "not found")
+	})
+	t.Run("ok", func(t *testing.T) {
+		require.Nil(t, errorx.FromGRPCStatus(nil))
+		require.Nil(t, errorx.FromGRPCStatus(status.New(codes.OK, "")))
+	})
+}
+
+func Test_FromError_GRPC(t *testing.T) {
+	t.Run("nil error", func(t *testing.T) {
+		require.Nil(t, errorx.FromError(nil))
+	})
+	t.Run("Error", func(t *testing.T) {
+		err := errorx.New(1001, "用户不存在")
+		require.Equal(t, errorx.FromError(fmt.Errorf("wrap: %w", err)), err)
+	})
+	t.Run("status error", func(t *testing.T) {
+		err := errorx.New(1001, "用户不存在")
+		gotErr := errorx.FromError(status.Error(codes.Unknown, "x"))
+		require.Equal(t, gotErr.Code(), int32(500))
+
+		gotErr = errorx.FromError(err.GRPCStatus().Err())
+		require.Equal(t, gotErr.Code(), int32(1001))
+		require.Equal(t, gotErr.Message(), "用户不存在")
+	})
+	t.Run("not Error", func(t *testing.T) {
+		gotErr := errorx.FromError(newTestError("内部错误"))
+		require.Equal(t, gotErr.Code(), int32(500))
+	})
+}
+
+func Test_Error_Status(t *testing.T) {
+	t.Run("nil Error", func(t *testing.T) {
+		var err *errorx.Error
+		require.Equal(t, err.HTTPStatus(), 200)
+		require.Equal(t, err.GRPCCode(), codes.OK)
+	})
+	t.Run("code as status", func(t *testing.T) {
+		err := errorx.New(404, "没有找到")
+		require.Equal(t, err.HTTPStatus(), 404)
+		require.Equal(t, err.GRPCCode(), codes.NotFound)
+
+		err = errorx.New(1001, "用户不存在")
+		require.Equal(t, err.HTTPStatus(), 500)
+		require.Equal(t, err.GRPCCode(), codes.Internal)
+	})
+	t.Run("explicit status", func(t *testing.T) {
+		err := errorx.NewWithStatus(404, 1001, "用户不存在")
+		require.Equal(t, err.Code(), int32(1001))
+		require.Equal(t, err.HTTPStatus(), 404)
+		require.Equal(t, err.GRPCCode(), codes.NotFound)
+
+		err = errorx.New(1001, "用户不存在", errorx.WithGRPCCode(codes.FailedPrecondition))
+		require.Equal(t, err.HTTPStatus(), 400)
+		require.Equal(t, err.GRPCCode(), codes.FailedPrecondition)
+
+		err = err.WithStatus(409).WithGRPCCode(codes.Aborted)
+		require.Equal(t, err.HTTPStatus(), 409)
+		require.Equal(t, err.GRPCCode(), codes.Aborted)
+	})
+	t.Run("round trip", func(t *testing.T) {
+		err := errorx.NewWithStatus(409, 1001, "用户已存在", errorx.WithGRPCCode(codes.AlreadyExists))
+
+		s := err.GRPCStatus()
+		require.Equal(t, s.Code(), codes.AlreadyExists)
+
+		gotErr := errorx.FromGRPCStatus(s)
+		require.Equal(t, gotErr.Code(), int32(1001))
+		require.Equal(t, gotErr.HTTPStatus(), 409)
+		require.Equal(t, gotErr.GRPCCode(), codes.AlreadyExists)
+		require.Equal(t, gotErr.Metadata(), map[string]string(nil))
+	})
+}
+
+func Test_Error_FieldViolations(t *testing.T) {
+	var nilErr *errorx.Error
+	require.Nil(t, nilErr.FieldViolations())
+
+	err := errorx.NewBadRequest(errorx.WithFieldViolations(
+		&errorx.FieldViolation{Field: "name", Tag: "required", Message: "name is required"},
+	)).WithFieldViolations(
+		&errorx.FieldViolation{Field: "items[0].id", Tag: "min", Param: "1", Message: "id must be at least 1"},
+	)
+	require.Len(t, err.FieldViolations(), 2)
+
+	gotErr := errorx.FromGRPCStatus(err.GRPCStatus())
+	require.Equal(t, gotErr.Code(), int32(400))
+	require.Equal(t, gotErr.FieldViolations(), []*errorx.FieldViolation{
+		{Field: "name", Message: "name is required"},
+		{Field: "items[0].id", Message: "id must be at least 1"},
+	})
+}
+
+func Test_Error_Is(t *testing.T) {
+	errUserNotExist := func() *errorx.Error { return errorx.New(1001, "用户不存在") }
+
+	err := fmt.Errorf("query user: %w", errUserNotExist().WithMetadata("uid", "1"))
+	require.True(t, errors.Is(err, errUserNotExist()))
+	require.False(t, errors.Is(err, errorx.New(1002, "用户已存在")))
+	require.False(t, errors.Is(err, newTestError("用户不存在")))
+	require.False(t, errors.Is(errorx.New(1001, "用户不存在"), (*errorx.Error)(nil)))
+
+	md, ok := errorx.MetadataFrom(err)
+	require.True(t, ok)
+	require.Equal(t, md, map[string]string{"uid": "1"})
+	_, ok = errorx.MetadataFrom(newTestError("内部错误"))
+	require.False(t, ok)
+}
+
+func Test_Error_Retry(t *testing.T) {
+	err := errorx.NewServiceUnavailable().WithRetry(3 * time.Second)
+	require.True(t, err.Retryable())
+	require.Equal(t, 3*time.Second, err.RetryDelay())
+
+	delay, ok := errorx.IsRetryable(fmt.Errorf("call: %w", err))
+	require.True(t, ok)
+	require.Equal(t, 3*time.Second, delay)
+	_, ok = errorx.IsRetryable(errorx.NewBadRequest())
+	require.False(t, ok)
+
+	e := errorx.FromError(err.GRPCStatus().Err())
+	require.True(t, e.Retryable())
+	require.Equal(t, 3*time.Second, e.RetryDelay())
+
+	e = errorx.FromError(errorx.NewServiceUnavailable(errorx.WithRetry(0)).GRPCStatus().Err())
+	require.True(t, e.Retryable())
+	require.Zero(t, e.RetryDelay())
+}
+
+func Test_Error_Reason(t *testing.T) {
+	err := errorx.New(1001, "用户不存在", errorx.WithReason("USER_NOT_EXIST"), errorx.WithDomain("user"))
+	require.Equal(t, "USER_NOT_EXIST", err.Reason())
+	require.Equal(t, "user", err.Domain())
+
+	gotErr := errorx.FromError(err.GRPCStatus().Err())
+	require.Equal(t, "USER_NOT_EXIST", gotErr.Reason())
+	require.Equal(t, "user", gotErr.Domain())
+	require.Equal(t, "用户不存在", gotErr.Message())
+
+	require.True(t, errors.Is(err, errorx.New(1001, "")))
+	require.True(t, errors.Is(err, errorx.New(1001, "", errorx.WithReason("USER_NOT_EXIST"))))
+	require.False(t, errors.Is(err, errorx.New(1001, "", errorx.WithReason("USER_EXIST"))))
+	require.False(t, errors.Is(err, errorx.New(1001, "", errorx.WithDomain("order"))))
+
+	var nilErr *errorx.Error
+	require.Empty(t, nilErr.Reason())
+	require.Empty(t, nilErr.Domain())
+}
+
+func Test_Error_MarshalJSON(t *testing.T) {
+	err := errorx.New(1001, "用户不存在",
+		errorx.WithReason("USER_NOT_EXIST"),
+		errorx.WithError("sql: no rows"),
+		errorx.WithMetadata("uid", "1"),
+	)
+	b, e := json.Marshal(err)
+	require.NoError(t, e)
+	require.JSONEq(t, `{"code":1001,"reason":"USER_NOT_EXIST","message":"用户不存在","metadata":{"uid":"1"}}`, string(b))
+
+	b, e = json.Marshal((*errorx.Error)(nil))
+	require.NoError(t, e)
+	require.Equal(t, "null", string(b))
+}
+
+func Test_Error_RequestId(t *testing.T) {
+	shared := errorx.NewNotFound()
+	ctx := transport.WithValueRequestId(context.Background(), "req-1")
+
+	err := shared.WithContextRequestId(ctx)
+	require.Equal(t, "req-1", err.RequestId())
+	require.Empty(t, shared.RequestId(), "the shared error is not modified")
+	require.Same(t, shared, shared.WithContextRequestId(context.Background()))
+	require.Equal(t, "req-0", errorx.NewNotFound(errorx.WithRequestId("req-0")).WithContextRequestId(ctx).RequestId())
+
+	b, e := json.Marshal(err)
+	require.NoError(t, e)
+	require.JSONEq(t, `{"code":404,"message":"没有找到,资源不存在","request_id":"req-1"}`, string(b))
+
+	gotErr := errorx.FromError(err.GRPCStatus().Err())
+	require.Equal(t, "req-1", gotErr.RequestId())
+}
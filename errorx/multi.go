@@ -0,0 +1,218 @@
+package errorx
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// metadataKeyIndex the key of the item index stored in the sub error `errdetails.ErrorInfo` metadata.
+const metadataKeyIndex = "errorx-index"
+
+// metadataKeyKey the key of the item key stored in the sub error `errdetails.ErrorInfo` metadata.
+const metadataKeyKey = "errorx-key"
+
+var _ error = (*MultiError)(nil)
+
+// ErrorItem is a sub error of the aggregate error, with the index or key of the failed item.
+type ErrorItem struct {
+	// Index the index of the item in the batch, -1 means not set.
+	Index int
+	// Key the key of the item in the batch, like the id, empty means not set.
+	Key string
+	// Err the error of the item.
+	Err *Error
+}
+
+// MarshalJSON implement json.Marshaler, the index is omitted if not set.
+func (i *ErrorItem) MarshalJSON() ([]byte, error) {
+	var index *int
+	if i.Index >= 0 {
+		index = &i.Index
+	}
+	return json.Marshal(struct {
+		Index *int   `json:"index,omitempty"`
+		Key   string `json:"key,omitempty"`
+		Err   *Error `json:"error"`
+	}{
+		Index: index,
+		Key:   i.Key,
+		Err:   i.Err,
+	})
+}
+
+// MultiError collects multiple errors, like the failures of a batch operation,
+// the zero value is ready to use.
+//
+// it supports `errors.Join` style unwrapping, so `errors.Is` matches any sub error,
+// while `errors.As` to `*Error` returns the aggregate error, see `Err`.
+type MultiError struct {
+	items []*ErrorItem
+}
+
+// Add adds the error of the item at index, nil error is ignored.
+func (m *MultiError) Add(index int, err error) *MultiError {
+	return m.add(index, "", err)
+}
+
+// AddKey adds the error of the item with key, nil error is ignored.
+func (m *MultiError) AddKey(key string, err error) *MultiError {
+	return m.add(-1, key, err)
+}
+
+func (m *MultiError) add(index int, key string, err error) *MultiError {
+	if e := Parse(err); e != nil {
+		m.items = append(m.items, &ErrorItem{Index: index, Key: key, Err: e})
+	}
+	return m
+}
+
+// Len returns the number of the errors.
+func (m *MultiError) Len() int { return len(m.items) }
+
+// Items returns the sub errors.
+func (m *MultiError) Items() []*ErrorItem { return m.items }
+
+// ErrorOrNil returns nil if no error collected, otherwise the MultiError itself.
+func (m *MultiError) ErrorOrNil() error {
+	if m == nil || len(m.items) == 0 {
+		return nil
+	}
+	return m
+}
+
+// Error implement `Error() string` interface.
+func (m *MultiError) Error() string {
+	b := strings.Builder{}
+	for i, item := range m.items {
+		if i > 0 {
+			b.WriteString("; ")
+		}
+		b.WriteString(item.label())
+		b.WriteString(item.Err.Error())
+	}
+	return b.String()
+}
+
+// Unwrap implement `Unwrap() []error` interface, same as `errors.Join`.
+func (m *MultiError) Unwrap() []error {
+	errs := make([]error, 0, len(m.items))
+	for _, item := range m.items {
+		errs = append(errs, item.Err)
+	}
+	return errs
+}
+
+// As implement `errors.As`, if target is `**Error`, set it to the aggregate error, see `Err`.
+func (m *MultiError) As(target any) bool {
+	if p, ok := target.(**Error); ok && len(m.items) > 0 {
+		*p = m.Err()
+		return true
+	}
+	return false
+}
+
+// Err returns the aggregate error which carries all sub errors, return nil if no error collected.
+// the status is converted with the DefaultConverter, see `ErrWith`.
+func (m *MultiError) Err() *Error {
+	return m.ErrWith(DefaultConverter)
+}
+
+// ErrWith returns the aggregate error which carries all sub errors, return nil if no error collected.
+// the overall status:
+//
+//	all sub errors have the same http status: the status.
+//	any sub error is 5xx: 500.
+//	otherwise: 400.
+//
+// the gRPC code is the same one if all sub errors have the same, otherwise converted from the overall status.
+// the status is converted with the converter, nil means DefaultConverter.
+func (m *MultiError) ErrWith(c Converter) *Error {
+	if m == nil || len(m.items) == 0 {
+		return nil
+	}
+	c = converterOrDefault(c)
+	httpStatus, grpcCode := m.items[0].Err.HTTPStatusWith(c), m.items[0].Err.GRPCCodeWith(c)
+	for _, item := range m.items[1:] {
+		if s := item.Err.HTTPStatusWith(c); s != httpStatus {
+			if s >= http.StatusInternalServerError || httpStatus >= http.StatusInternalServerError {
+				httpStatus = http.StatusInternalServerError
+			} else {
+				httpStatus = http.StatusBadRequest
+			}
+		}
+		if code := item.Err.GRPCCodeWith(c); code != grpcCode {
+			grpcCode = codes.OK
+		}
+	}
+	if grpcCode == codes.OK {
+		grpcCode = c.ToGRPCCode(httpStatus)
+	}
+	return &Error{
+		code:     int32(httpStatus),
+		status:   httpStatus,
+		grpcCode: grpcCode,
+		message:  "批量操作失败",
+		reason:   "MULTIPLE_ERRORS",
+		cause:    m,
+		items:    m.items,
+		stack:    autoCallers(),
+	}
+}
+
+// Items get the sub errors if it is an aggregate error.
+func (e *Error) Items() []*ErrorItem {
+	if e == nil {
+		return nil
+	}
+	return e.items
+}
+
+func (i *ErrorItem) label() string {
+	switch {
+	case i.Key != "":
+		return "[" + i.Key + "] "
+	case i.Index >= 0:
+		return "[" + strconv.Itoa(i.Index) + "] "
+	default:
+		return ""
+	}
+}
+
+// intoStatus convert the sub error into `spb.Status`, the index and key are stored in the metadata.
+func (i *ErrorItem) intoStatus(c Converter) *spb.Status {
+	e := i.Err.clone()
+	if i.Index >= 0 {
+		WithMetadata(metadataKeyIndex, strconv.Itoa(i.Index))(e)
+	}
+	WithMetadata(metadataKeyKey, i.Key)(e)
+	return e.GRPCStatusWith(c).Proto()
+}
+
+// fromStatus convert `spb.Status` into the sub error, it is the inverse of `intoStatus`.
+func fromStatus(s *spb.Status, c Converter) *ErrorItem {
+	e := FromGRPCStatusWith(status.FromProto(s), c)
+	if e == nil {
+		return nil
+	}
+	item := &ErrorItem{Index: -1, Err: e}
+	if v, ok := e.metadata[metadataKeyIndex]; ok {
+		if index, err := strconv.Atoi(v); err == nil {
+			item.Index = index
+		}
+		delete(e.metadata, metadataKeyIndex)
+	}
+	if v, ok := e.metadata[metadataKeyKey]; ok {
+		item.Key = v
+		delete(e.metadata, metadataKeyKey)
+	}
+	if len(e.metadata) == 0 {
+		e.metadata = nil
+	}
+	return item
+}
@@ -0,0 +1,94 @@
+package errorx_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+
+	"github.com/things-go/dyn/errorx"
+)
+
+func Test_MultiError(t *testing.T) {
+	errUserNotExist := func() *errorx.Error {
+		return errorx.New(1001, "用户不存在", errorx.WithStatus(http.StatusNotFound))
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		var m errorx.MultiError
+		m.Add(0, nil)
+		require.Zero(t, m.Len())
+		require.NoError(t, m.ErrorOrNil())
+		require.Nil(t, m.Err())
+	})
+	t.Run("unwrap", func(t *testing.T) {
+		var m errorx.MultiError
+		m.Add(0, errUserNotExist()).AddKey("u2", errUserNotExist().WithError("sql: no rows"))
+		require.Equal(t, 2, m.Len())
+		require.Equal(t, "[0] 用户不存在; [u2] 用户不存在: sql: no rows", m.Error())
+
+		err := fmt.Errorf("bulk delete: %w", m.ErrorOrNil())
+		require.True(t, errors.Is(err, errUserNotExist()))
+		require.False(t, errors.Is(err, errorx.New(1002, "用户已存在")))
+
+		e := errorx.Parse(err)
+		require.Equal(t, int32(http.StatusNotFound), e.Code())
+		require.Equal(t, http.StatusNotFound, e.HTTPStatus())
+		require.Equal(t, codes.NotFound, e.GRPCCode())
+		require.Equal(t, "MULTIPLE_ERRORS", e.Reason())
+		require.Len(t, e.Items(), 2)
+	})
+	t.Run("overall status", func(t *testing.T) {
+		var m errorx.MultiError
+		m.Add(0, errUserNotExist()).Add(1, errorx.NewConflict())
+		require.Equal(t, http.StatusBadRequest, m.Err().HTTPStatus())
+		require.Equal(t, codes.InvalidArgument, m.Err().GRPCCode())
+
+		m.Add(2, errors.New("内部错误"))
+		require.Equal(t, http.StatusInternalServerError, m.Err().HTTPStatus())
+	})
+	t.Run("converter", func(t *testing.T) {
+		c := errorx.NewTableConverter(errorx.WithMapping(http.StatusConflict, codes.AlreadyExists))
+		var m errorx.MultiError
+		m.Add(0, errorx.NewConflict()).Add(1, errorx.NewConflict())
+		require.Equal(t, codes.Aborted, m.Err().GRPCCode())
+		require.Equal(t, codes.AlreadyExists, m.ErrWith(c).GRPCCode())
+		require.Equal(t, http.StatusConflict, m.ErrWith(c).HTTPStatus())
+
+		m.Add(2, errUserNotExist())
+		require.Equal(t, codes.InvalidArgument, m.ErrWith(c).GRPCCode())
+	})
+	t.Run("grpc status", func(t *testing.T) {
+		var m errorx.MultiError
+		m.Add(0, errUserNotExist().WithMetadata("uid", "1")).AddKey("u2", errorx.NewConflict())
+
+		gotErr := errorx.FromError(m.Err().GRPCStatus().Err())
+		require.Equal(t, "MULTIPLE_ERRORS", gotErr.Reason())
+		items := gotErr.Items()
+		require.Len(t, items, 2)
+		require.Equal(t, 0, items[0].Index)
+		require.Equal(t, int32(1001), items[0].Err.Code())
+		require.Equal(t, map[string]string{"uid": "1"}, items[0].Err.Metadata())
+		require.Equal(t, -1, items[1].Index)
+		require.Equal(t, "u2", items[1].Key)
+		require.Equal(t, int32(http.StatusConflict), items[1].Err.Code())
+	})
+	t.Run("json", func(t *testing.T) {
+		var m errorx.MultiError
+		m.Add(0, errUserNotExist()).AddKey("u2", errUserNotExist())
+
+		b, err := json.Marshal(m.Err())
+		require.NoError(t, err)
+		require.JSONEq(t, `{
+			"code":404,"reason":"MULTIPLE_ERRORS","message":"批量操作失败",
+			"errors":[
+				{"index":0,"error":{"code":1001,"message":"用户不存在"}},
+				{"key":"u2","error":{"code":1001,"message":"用户不存在"}}
+			]
+		}`, string(b))
+	})
+}
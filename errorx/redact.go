@@ -0,0 +1,75 @@
+package errorx
+
+import (
+	"fmt"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+
+	"github.com/things-go/dyn/pkg/deploy"
+)
+
+// WithSensitiveMetadata add metadata which is only available to the server side,
+// it is stripped by `Redact`.
+func WithSensitiveMetadata(k, v string) Option {
+	return func(e *Error) {
+		if k != "" && v != "" {
+			WithMetadata(k, v)(e)
+			if e.sensitive == nil {
+				e.sensitive = make(map[string]struct{})
+			}
+			e.sensitive[k] = struct{}{}
+		}
+	}
+}
+
+// WithSensitiveMetadata add metadata which is only available to the server side,
+// it is stripped by `Redact`.
+func (e *Error) WithSensitiveMetadata(k, v string) *Error {
+	return e.TakeOption(WithSensitiveMetadata(k, v))
+}
+
+// Redact returns a copy of the error which is safe to expose to the client,
+// the cause, stack trace and sensitive metadata are stripped.
+// the original error keeps all of them for the server side logging.
+func (e *Error) Redact() *Error {
+	if e == nil {
+		return nil
+	}
+	ce := *e
+	ce.cause = nil
+	ce.stack = nil
+	ce.sensitive = nil
+	ce.metadata = nil
+	for k, v := range e.metadata {
+		if _, ok := e.sensitive[k]; !ok {
+			WithMetadata(k, v)(&ce)
+		}
+	}
+	return &ce
+}
+
+// Public returns the error exposed to the client.
+// only if `deploy.IsTesting()`(dev or test), the error itself, the internal details are kept for debugging,
+// otherwise it is redacted via `Redact`, include the unset deploy mode.
+func (e *Error) Public() *Error {
+	if deploy.IsTesting() {
+		return e
+	}
+	return e.Redact()
+}
+
+// intoDebugInfo convert the cause and stack trace into `errdetails.DebugInfo`,
+// return nil if both are absent.
+func (e *Error) intoDebugInfo() *errdetails.DebugInfo {
+	if e.cause == nil && len(e.stack) == 0 {
+		return nil
+	}
+	di := &errdetails.DebugInfo{}
+	if e.cause != nil {
+		di.Detail = e.cause.Error()
+	}
+	for _, frame := range e.stack.Frames() {
+		di.StackEntries = append(di.StackEntries, fmt.Sprintf("%s\n\t%s:%d", frame.Function, frame.File, frame.Line))
+	}
+	return di
+}
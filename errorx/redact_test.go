@@ -0,0 +1,66 @@
+package errorx_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+
+	"github.com/things-go/dyn/errorx"
+	"github.com/things-go/dyn/pkg/deploy"
+)
+
+func Test_Error_Redact(t *testing.T) {
+	newErr := func() *errorx.Error {
+		return errorx.NewInternalServer(
+			errorx.WithError("sql: connection refused"),
+			errorx.WithMetadata("uid", "1"),
+			errorx.WithSensitiveMetadata("dsn", "root@tcp(127.0.0.1:3306)"),
+			errorx.WithStack(),
+		)
+	}
+
+	t.Run("redact", func(t *testing.T) {
+		err := newErr()
+		pe := err.Redact()
+		require.Equal(t, "服务器错误", pe.Error())
+		require.Nil(t, pe.Unwrap())
+		require.Empty(t, pe.StackTrace())
+		require.Equal(t, map[string]string{"uid": "1"}, pe.Metadata())
+		// the original error keeps the internal details.
+		require.Equal(t, "服务器错误: sql: connection refused", err.Error())
+		require.Len(t, err.Metadata(), 2)
+		require.NotEmpty(t, err.StackTrace())
+
+		var nilErr *errorx.Error
+		require.Nil(t, nilErr.Redact())
+	})
+	t.Run("testing", func(t *testing.T) {
+		deploy.Set(deploy.Dev)
+		t.Cleanup(func() { deploy.Set(deploy.None) })
+
+		err := newErr()
+		require.Same(t, err, err.Public())
+
+		gotErr := errorx.FromError(err.GRPCStatus().Err())
+		require.Equal(t, "服务器错误: sql: connection refused", gotErr.Error())
+		require.Len(t, gotErr.Metadata(), 2)
+	})
+	for _, mode := range []deploy.Mode{deploy.None, deploy.Uat, deploy.Prod} {
+		t.Run("redacted in "+mode.String(), func(t *testing.T) {
+			deploy.Set(mode)
+			t.Cleanup(func() { deploy.Set(deploy.None) })
+
+			err := newErr()
+			require.Equal(t, "服务器错误", err.Public().Error())
+
+			st := err.GRPCStatus()
+			for _, detail := range st.Details() {
+				require.IsType(t, &errdetails.ErrorInfo{}, detail)
+			}
+			gotErr := errorx.FromError(st.Err())
+			require.Equal(t, "服务器错误", gotErr.Error())
+			require.Equal(t, map[string]string{"uid": "1"}, gotErr.Metadata())
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package errorx
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+)
+
+var _ http.Handler = (*Registry)(nil)
+
+// DefaultRegistry the default registry, generated errno packages register into it.
+var DefaultRegistry = NewRegistry()
+
+// Entry is a registered error definition.
+type Entry struct {
+	Code     int32  `json:"code"`
+	Status   int    `json:"status"`
+	GRPCCode string `json:"grpcCode"`
+	Message  string `json:"message"`
+	Reason   string `json:"reason,omitempty"`
+	Domain   string `json:"domain,omitempty"`
+	// Source the source type which defines the error, like `github.com/things-go/dyn/example/errno.BizError`
+	Source string `json:"source"`
+}
+
+// Registry is a registry of error codes, it detects the duplicate codes across sources.
+type Registry struct {
+	mu      sync.RWMutex
+	entries map[int32]*Entry
+}
+
+// NewRegistry new registry
+func NewRegistry() *Registry {
+	return &Registry{
+		entries: make(map[int32]*Entry),
+	}
+}
+
+// Register registers the errors defined by the source,
+// returns error if the code has been registered by another source or with another message.
+func (r *Registry) Register(source string, errs ...*Error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, e := range errs {
+		if e == nil {
+			continue
+		}
+		entry := &Entry{
+			Code:     e.Code(),
+			Status:   e.HTTPStatus(),
+			GRPCCode: e.GRPCCode().String(),
+			Message:  e.Message(),
+			Reason:   e.Reason(),
+			Domain:   e.Domain(),
+			Source:   source,
+		}
+		if old, ok := r.entries[entry.Code]; ok {
+			if *old == *entry {
+				continue
+			}
+			return fmt.Errorf("errorx: duplicate code %d, registered by %s(%s), conflict with %s(%s)",
+				entry.Code, old.Source, old.Message, entry.Source, entry.Message)
+		}
+		r.entries[entry.Code] = entry
+	}
+	return nil
+}
+
+// MustRegister same as Register, but panic if duplicate code.
+func (r *Registry) MustRegister(source string, errs ...*Error) {
+	if err := r.Register(source, errs...); err != nil {
+		panic(err)
+	}
+}
+
+// Lookup returns the entry of the code, if any.
+func (r *Registry) Lookup(code int32) (Entry, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	entry, ok := r.entries[code]
+	if !ok {
+		return Entry{}, false
+	}
+	return *entry, true
+}
+
+// Entries returns all registered entries sorted by code.
+func (r *Registry) Entries() []Entry {
+	r.mu.RLock()
+	entries := make([]Entry, 0, len(r.entries))
+	for _, entry := range r.entries {
+		entries = append(entries, *entry)
+	}
+	r.mu.RUnlock()
+	slices.SortFunc(entries, func(a, b Entry) int { return cmp.Compare(a.Code, b.Code) })
+	return entries
+}
+
+// ServeHTTP implement http.Handler, serve all registered entries as json catalog.
+func (r *Registry) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	data, err := json.Marshal(r.Entries())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
+
+// Register registers the errors defined by the source into DefaultRegistry.
+func Register(source string, errs ...*Error) error {
+	return DefaultRegistry.Register(source, errs...)
+}
+
+// MustRegister registers the errors defined by the source into DefaultRegistry, panic if duplicate code.
+func MustRegister(source string, errs ...*Error) {
+	DefaultRegistry.MustRegister(source, errs...)
+}
+
+// Entries returns all entries registered in DefaultRegistry.
+func Entries() []Entry {
+	return DefaultRegistry.Entries()
+}
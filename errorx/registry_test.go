@@ -0,0 +1,55 @@
+package errorx_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/things-go/dyn/errorx"
+)
+
+func Test_Registry(t *testing.T) {
+	r := errorx.NewRegistry()
+
+	err := r.Register("errno.BizError",
+		errorx.New(1001, "用户不存在"),
+		errorx.NewWithStatus(504, 1000, "操作超时"),
+	)
+	require.NoError(t, err)
+	// same definition register again
+	err = r.Register("errno.BizError", errorx.New(1001, "用户不存在"))
+	require.NoError(t, err)
+	// duplicate code across sources
+	err = r.Register("errnop.ErrorReason", errorx.New(1001, "用户名或密码错误"))
+	require.Error(t, err)
+	require.Panics(t, func() {
+		r.MustRegister("errnop.ErrorReason", errorx.New(1000, "用户名或密码错误"))
+	})
+
+	entry, ok := r.Lookup(1000)
+	require.True(t, ok)
+	require.Equal(t, entry, errorx.Entry{
+		Code:     1000,
+		Status:   504,
+		GRPCCode: "DeadlineExceeded",
+		Message:  "操作超时",
+		Source:   "errno.BizError",
+	})
+	_, ok = r.Lookup(1002)
+	require.False(t, ok)
+
+	entries := r.Entries()
+	require.Len(t, entries, 2)
+	require.Equal(t, entries[0].Code, int32(1000))
+	require.Equal(t, entries[1].Code, int32(1001))
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/errors", nil))
+	require.Equal(t, w.Code, http.StatusOK)
+	require.JSONEq(t, w.Body.String(), `[
+		{"code":1000,"status":504,"grpcCode":"DeadlineExceeded","message":"操作超时","source":"errno.BizError"},
+		{"code":1001,"status":500,"grpcCode":"Internal","message":"用户不存在","source":"errno.BizError"}
+	]`)
+}
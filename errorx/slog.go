@@ -0,0 +1,86 @@
+package errorx
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+)
+
+var _ slog.LogValuer = (*Error)(nil)
+
+// LogValue implement slog.LogValuer, the error is logged as a group:
+// code, status, message, reason, domain, metadata, violations, sub errors, retry delay, cause chain and stack trace(if captured).
+// NOTE: it contains the internal details, it is used for the server side logging only.
+func (e *Error) LogValue() slog.Value {
+	if e == nil {
+		return slog.StringValue("<nil>")
+	}
+	attrs := []slog.Attr{
+		slog.Int64("code", int64(e.code)),
+		slog.Int("status", e.HTTPStatus()),
+		slog.String("message", e.message),
+	}
+	if e.reason != "" {
+		attrs = append(attrs, slog.String("reason", e.reason))
+	}
+	if e.domain != "" {
+		attrs = append(attrs, slog.String("domain", e.domain))
+	}
+	if len(e.metadata) > 0 {
+		keys := make([]string, 0, len(e.metadata))
+		for k := range e.metadata {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		mds := make([]any, 0, len(keys))
+		for _, k := range keys {
+			mds = append(mds, slog.String(k, e.metadata[k]))
+		}
+		attrs = append(attrs, slog.Group("metadata", mds...))
+	}
+	if len(e.fieldViolations) > 0 {
+		vs := make([]string, 0, len(e.fieldViolations))
+		for _, v := range e.fieldViolations {
+			vs = append(vs, v.Field+": "+v.Message)
+		}
+		attrs = append(attrs, slog.Any("violations", vs))
+	}
+	if len(e.items) > 0 {
+		errs := make([]string, 0, len(e.items))
+		for _, item := range e.items {
+			errs = append(errs, item.label()+item.Err.Error())
+		}
+		attrs = append(attrs, slog.Any("errors", errs))
+	}
+	if e.retryable {
+		attrs = append(attrs, slog.Duration("retry", e.retryDelay))
+	}
+	if causes := causeChain(e.cause); len(causes) > 0 {
+		attrs = append(attrs, slog.Any("causes", causes))
+	}
+	if frames := e.stack.Frames(); len(frames) > 0 {
+		stack := make([]string, 0, len(frames))
+		for _, frame := range frames {
+			stack = append(stack, fmt.Sprintf("%s %s:%d", frame.Function, frame.File, frame.Line))
+		}
+		attrs = append(attrs, slog.Any("stack", stack))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// causeChain returns the messages of the cause chain,
+// the chain continues through `Error` and stops at the first other error,
+// because its message already contains the errors it wraps.
+func causeChain(err error) []string {
+	var causes []string
+	for err != nil {
+		te, ok := err.(*Error)
+		if !ok || te == nil {
+			causes = append(causes, err.Error())
+			break
+		}
+		causes = append(causes, te.message)
+		err = te.cause
+	}
+	return causes
+}
@@ -0,0 +1,43 @@
+package errorx_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/things-go/dyn/errorx"
+)
+
+func Test_Error_LogValue(t *testing.T) {
+	err := errorx.New(1001, "用户不存在",
+		errorx.WithCause(errorx.New(500, "查询失败", errorx.WithError("sql: no rows"))),
+		errorx.WithMetadata("uid", "1"),
+	)
+
+	buf := &bytes.Buffer{}
+	slog.New(slog.NewJSONHandler(buf, nil)).Error("failed", "error", err)
+
+	var got struct {
+		Error struct {
+			Code     int32             `json:"code"`
+			Status   int               `json:"status"`
+			Message  string            `json:"message"`
+			Metadata map[string]string `json:"metadata"`
+			Causes   []string          `json:"causes"`
+			Stack    []string          `json:"stack"`
+		} `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	require.Equal(t, int32(1001), got.Error.Code)
+	require.Equal(t, 500, got.Error.Status)
+	require.Equal(t, "用户不存在", got.Error.Message)
+	require.Equal(t, map[string]string{"uid": "1"}, got.Error.Metadata)
+	require.Equal(t, []string{"查询失败", "sql: no rows"}, got.Error.Causes)
+	require.Empty(t, got.Error.Stack)
+
+	var nilErr *errorx.Error
+	require.Equal(t, "<nil>", nilErr.LogValue().String())
+}
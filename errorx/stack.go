@@ -0,0 +1,144 @@
+package errorx
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+const maxStackDepth = 32
+
+// pkgPrefix the function name prefix of this package, used to skip the frames inside this package.
+var pkgPrefix = reflect.TypeOf(Error{}).PkgPath() + "."
+
+// stack represents a stack of program counters.
+type stack []uintptr
+
+// callers captures the stack of the caller, the frames inside this package are skipped.
+func callers() stack {
+	var pcs [maxStackDepth]uintptr
+
+	n := runtime.Callers(3, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+	skip := 0
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, pkgPrefix) {
+			break
+		}
+		skip++
+		if !more {
+			break
+		}
+	}
+	return slices.Clone(pcs[skip:n])
+}
+
+// Frames returns the resolved frames of the stack.
+func (s stack) Frames() []runtime.Frame {
+	if len(s) == 0 {
+		return nil
+	}
+	frames := make([]runtime.Frame, 0, len(s))
+	it := runtime.CallersFrames(s)
+	for {
+		frame, more := it.Next()
+		frames = append(frames, frame)
+		if !more {
+			break
+		}
+	}
+	return frames
+}
+
+// WithStack capture the stack trace where the error is created.
+// NOTE: the stack is captured automatically when `deploy.IsTesting()`.
+func WithStack() Option {
+	return func(e *Error) {
+		e.stack = callers()
+	}
+}
+
+// FromPanic convert the recovered panic value into `NewInternalServer` with the stack captured,
+// the panic value is the cause, so it is hidden from the client unless `deploy.IsTesting()`.
+func FromPanic(r any) *Error {
+	var cause error
+	if err, ok := r.(error); ok {
+		cause = fmt.Errorf("panic: %w", err)
+	} else {
+		cause = fmt.Errorf("panic: %v", r)
+	}
+	return NewInternalServer(WithCause(cause), WithStack())
+}
+
+// StackTrace returns the stack trace where the error is created, if captured.
+func (e *Error) StackTrace() []runtime.Frame {
+	if e == nil {
+		return nil
+	}
+	return e.stack.Frames()
+}
+
+// Format implement fmt.Formatter.
+//
+//	%s, %v: same as Error()
+//	%q:     quoted Error()
+//	%+v:    message, code, status, reason, metadata, field violations, sub errors, cause chain and stack trace.
+//	others: %!verb(*errorx.Error=Error()), like fmt does for the bad verb.
+func (e *Error) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') && e != nil {
+			e.formatDetail(s)
+			return
+		}
+		_, _ = io.WriteString(s, e.Error())
+	case 's':
+		_, _ = io.WriteString(s, e.Error())
+	case 'q':
+		_, _ = fmt.Fprintf(s, "%q", e.Error())
+	default:
+		_, _ = fmt.Fprintf(s, "%%!%c(*errorx.Error=%s)", verb, e.Error())
+	}
+}
+
+func (e *Error) formatDetail(w io.Writer) {
+	_, _ = io.WriteString(w, e.message)
+	_, _ = fmt.Fprintf(w, "\n    code: %d", e.code)
+	_, _ = fmt.Fprintf(w, "\n    status: %d(%s)", e.HTTPStatus(), e.GRPCCode())
+	if e.reason != "" {
+		_, _ = fmt.Fprintf(w, "\n    reason: %s", e.reason)
+		if e.domain != "" {
+			_, _ = fmt.Fprintf(w, "(%s)", e.domain)
+		}
+	}
+	if len(e.metadata) > 0 {
+		keys := make([]string, 0, len(e.metadata))
+		for k := range e.metadata {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		_, _ = io.WriteString(w, "\n    metadata:")
+		for _, k := range keys {
+			_, _ = fmt.Fprintf(w, " %s=%s", k, e.metadata[k])
+		}
+	}
+	for _, v := range e.fieldViolations {
+		_, _ = fmt.Fprintf(w, "\n    violation: %s(%s=%s) %s", v.Field, v.Tag, v.Param, v.Message)
+	}
+	for _, item := range e.items {
+		_, _ = fmt.Fprintf(w, "\n    error: %s%s", item.label(), item.Err.Error())
+	}
+	if e.retryable {
+		_, _ = fmt.Fprintf(w, "\n    retry: after %s", e.retryDelay)
+	}
+	if e.cause != nil {
+		_, _ = fmt.Fprintf(w, "\n    cause: %+v", e.cause)
+	}
+	for _, frame := range e.stack.Frames() {
+		_, _ = fmt.Fprintf(w, "\n%s\n\t%s:%d", frame.Function, frame.File, frame.Line)
+	}
+}
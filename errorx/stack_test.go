@@ -0,0 +1,74 @@
+package errorx_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/things-go/dyn/errorx"
+	"github.com/things-go/dyn/pkg/deploy"
+)
+
+func Test_Error_StackTrace(t *testing.T) {
+	t.Run("without stack", func(t *testing.T) {
+		var err *errorx.Error
+		require.Nil(t, err.StackTrace())
+
+		err = errorx.New(400, "请求参数错误")
+		require.Nil(t, err.StackTrace())
+	})
+	t.Run("with stack", func(t *testing.T) {
+		err := errorx.New(400, "请求参数错误", errorx.WithStack())
+
+		frames := err.StackTrace()
+		require.NotEmpty(t, frames)
+		require.True(t, strings.HasSuffix(frames[0].Function, "Test_Error_StackTrace.func2"))
+	})
+	t.Run("testing mode", func(t *testing.T) {
+		deploy.Set(deploy.Dev)
+		t.Cleanup(func() { deploy.Set(deploy.None) })
+
+		err := errorx.Parse(errors.New("内部错误"))
+		frames := err.StackTrace()
+		require.NotEmpty(t, frames)
+		require.True(t, strings.HasSuffix(frames[0].Function, "Test_Error_StackTrace.func3"))
+	})
+}
+
+func Test_Error_Format(t *testing.T) {
+	err := errorx.New(400, "请求参数错误",
+		errorx.WithError("内部错误"),
+		errorx.WithMetadata("k2", "v2"),
+		errorx.WithMetadata("k1", "v1"),
+		errorx.WithStack(),
+	)
+	require.Equal(t, fmt.Sprintf("%s", err), "请求参数错误: 内部错误")
+	require.Equal(t, fmt.Sprintf("%v", err), "请求参数错误: 内部错误")
+	require.Equal(t, fmt.Sprintf("%q", err), `"请求参数错误: 内部错误"`)
+	require.Equal(t, fmt.Sprintf("%d|%x", err, err), "%!d(*errorx.Error=请求参数错误: 内部错误)|%!x(*errorx.Error=请求参数错误: 内部错误)")
+
+	got := fmt.Sprintf("%+v", err)
+	require.True(t, strings.HasPrefix(got, "请求参数错误\n    code: 400\n    status: 400(InvalidArgument)\n    metadata: k1=v1 k2=v2\n    cause: 内部错误\n"))
+	require.Contains(t, got, "Test_Error_Format")
+
+	var nilErr *errorx.Error
+	require.Equal(t, fmt.Sprintf("%+v", nilErr), "<nil>")
+}
+
+func Test_FromPanic(t *testing.T) {
+	err := func() (err *errorx.Error) {
+		defer func() {
+			err = errorx.FromPanic(recover())
+		}()
+		panic("boom")
+	}()
+	require.Equal(t, int32(500), err.Code())
+	require.Equal(t, "服务器错误: panic: boom", err.Error())
+	require.NotEmpty(t, err.StackTrace())
+
+	cause := errors.New("boom")
+	require.ErrorIs(t, errorx.FromPanic(cause), cause)
+}
@@ -1,6 +1,7 @@
 package errorx
 
 import (
+	"maps"
 	"net/http"
 
 	"google.golang.org/grpc/codes"
@@ -20,82 +21,157 @@ type Converter interface {
 	FromGRPCCode(code codes.Code) int
 }
 
-type statusConverter struct{}
+// DefaultConverter the default converter used by `ToGRPCCode`, `FromGRPCCode` and
+// the methods without a converter argument.
+// NOTE: prefer injecting a converter at the use site to mutating it.
+var DefaultConverter Converter = NewTableConverter()
 
-var DefaultConverter Converter = statusConverter{}
+// defaultToGRPCCodes the default HTTP status --> gRPC code table.
+// it is consistent with defaultFromGRPCCodes, the mapped ones round-trip,
+// except the statuses collapse into the same gRPC code:
+//
+//	412 --> FailedPrecondition --> 400
+//	416 --> OutOfRange --> 400
+//	422 --> InvalidArgument --> 400
+//	405 --> Unimplemented --> 501
+//	408 --> DeadlineExceeded --> 504
+//	502 --> Unavailable --> 503
+//
+// See: https://github.com/googleapis/googleapis/blob/master/google/rpc/code.proto
+var defaultToGRPCCodes = map[int]codes.Code{
+	http.StatusOK:                           codes.OK,
+	http.StatusBadRequest:                   codes.InvalidArgument,
+	http.StatusUnauthorized:                 codes.Unauthenticated,
+	http.StatusForbidden:                    codes.PermissionDenied,
+	http.StatusNotFound:                     codes.NotFound,
+	http.StatusMethodNotAllowed:             codes.Unimplemented,
+	http.StatusRequestTimeout:               codes.DeadlineExceeded,
+	http.StatusConflict:                     codes.Aborted,
+	http.StatusPreconditionFailed:           codes.FailedPrecondition,
+	http.StatusRequestedRangeNotSatisfiable: codes.OutOfRange,
+	http.StatusUnprocessableEntity:          codes.InvalidArgument,
+	http.StatusTooManyRequests:              codes.ResourceExhausted,
+	ClientClosed:                            codes.Canceled,
+	http.StatusInternalServerError:          codes.Internal,
+	http.StatusNotImplemented:               codes.Unimplemented,
+	http.StatusBadGateway:                   codes.Unavailable,
+	http.StatusServiceUnavailable:           codes.Unavailable,
+	http.StatusGatewayTimeout:               codes.DeadlineExceeded,
+}
 
-// ToGRPCCode converts a HTTP error code into the corresponding gRPC response status.
+// defaultFromGRPCCodes the default gRPC code --> HTTP status table.
+// it follows the HTTP mapping of code.proto, the mapped ones round-trip,
+// except the gRPC codes sharing the HTTP status collapse:
+//
+//	Unknown --> 500 --> Internal
+//	AlreadyExists --> 409 --> Aborted
+//	FailedPrecondition --> 400 --> InvalidArgument
+//	OutOfRange --> 400 --> InvalidArgument
+//	DataLoss --> 500 --> Internal
+//
+// use `WithMapping` to keep them, like `WithMapping(http.StatusPreconditionFailed, codes.FailedPrecondition)`.
 // See: https://github.com/googleapis/googleapis/blob/master/google/rpc/code.proto
-func (c statusConverter) ToGRPCCode(code int) codes.Code {
-	switch code {
-	case http.StatusOK:
-		return codes.OK
-	case http.StatusBadRequest:
-		return codes.InvalidArgument
-	case http.StatusUnauthorized:
-		return codes.Unauthenticated
-	case http.StatusForbidden:
-		return codes.PermissionDenied
-	case http.StatusNotFound:
-		return codes.NotFound
-	case http.StatusConflict:
-		return codes.Aborted
-	case http.StatusTooManyRequests:
-		return codes.ResourceExhausted
-	case http.StatusInternalServerError:
-		return codes.Internal
-	case http.StatusNotImplemented:
-		return codes.Unimplemented
-	case http.StatusServiceUnavailable:
-		return codes.Unavailable
-	case http.StatusGatewayTimeout:
-		return codes.DeadlineExceeded
-	case ClientClosed:
-		return codes.Canceled
+var defaultFromGRPCCodes = map[codes.Code]int{
+	codes.OK:                 http.StatusOK,
+	codes.Canceled:           ClientClosed,
+	codes.Unknown:            http.StatusInternalServerError,
+	codes.InvalidArgument:    http.StatusBadRequest,
+	codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+	codes.NotFound:           http.StatusNotFound,
+	codes.AlreadyExists:      http.StatusConflict,
+	codes.PermissionDenied:   http.StatusForbidden,
+	codes.Unauthenticated:    http.StatusUnauthorized,
+	codes.ResourceExhausted:  http.StatusTooManyRequests,
+	codes.FailedPrecondition: http.StatusBadRequest,
+	codes.Aborted:            http.StatusConflict,
+	codes.OutOfRange:         http.StatusBadRequest,
+	codes.Unimplemented:      http.StatusNotImplemented,
+	codes.Internal:           http.StatusInternalServerError,
+	codes.Unavailable:        http.StatusServiceUnavailable,
+	codes.DataLoss:           http.StatusInternalServerError,
+}
+
+var _ Converter = (*TableConverter)(nil)
+
+// TableConverter is a table-driven Converter, the default tables can be extended or overridden
+// with the ConverterOption.
+type TableConverter struct {
+	toGRPCCodes   map[int]codes.Code
+	fromGRPCCodes map[codes.Code]int
+	// unknownGRPCCode the gRPC code of the HTTP status not in the table.
+	unknownGRPCCode codes.Code
+	// unknownHTTPStatus the HTTP status of the gRPC code not in the table.
+	unknownHTTPStatus int
+}
+
+// ConverterOption table converter option
+type ConverterOption func(*TableConverter)
+
+// WithToGRPCCode add or override the mapping of the HTTP status to the gRPC code.
+func WithToGRPCCode(status int, code codes.Code) ConverterOption {
+	return func(c *TableConverter) {
+		c.toGRPCCodes[status] = code
 	}
-	return codes.Unknown
+}
+
+// WithFromGRPCCode add or override the mapping of the gRPC code to the HTTP status.
+func WithFromGRPCCode(code codes.Code, status int) ConverterOption {
+	return func(c *TableConverter) {
+		c.fromGRPCCodes[code] = status
+	}
+}
+
+// WithMapping add or override the mapping in both directions.
+func WithMapping(status int, code codes.Code) ConverterOption {
+	return func(c *TableConverter) {
+		c.toGRPCCodes[status] = code
+		c.fromGRPCCodes[code] = status
+	}
+}
+
+// WithUnknownGRPCCode set the gRPC code of the HTTP status not in the table, default codes.Unknown.
+func WithUnknownGRPCCode(code codes.Code) ConverterOption {
+	return func(c *TableConverter) {
+		c.unknownGRPCCode = code
+	}
+}
+
+// WithUnknownHTTPStatus set the HTTP status of the gRPC code not in the table, default 500.
+func WithUnknownHTTPStatus(status int) ConverterOption {
+	return func(c *TableConverter) {
+		c.unknownHTTPStatus = status
+	}
+}
+
+// NewTableConverter new table converter with the default tables,
+// the options extend or override the tables.
+func NewTableConverter(opts ...ConverterOption) *TableConverter {
+	c := &TableConverter{
+		toGRPCCodes:       maps.Clone(defaultToGRPCCodes),
+		fromGRPCCodes:     maps.Clone(defaultFromGRPCCodes),
+		unknownGRPCCode:   codes.Unknown,
+		unknownHTTPStatus: http.StatusInternalServerError,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// ToGRPCCode converts a HTTP error code into the corresponding gRPC response status.
+func (c *TableConverter) ToGRPCCode(code int) codes.Code {
+	if v, ok := c.toGRPCCodes[code]; ok {
+		return v
+	}
+	return c.unknownGRPCCode
 }
 
 // FromGRPCCode converts a gRPC error code into the corresponding HTTP response status.
-// See: https://github.com/googleapis/googleapis/blob/master/google/rpc/code.proto
-func (c statusConverter) FromGRPCCode(code codes.Code) int {
-	switch code {
-	case codes.OK:
-		return http.StatusOK
-	case codes.Canceled:
-		return ClientClosed
-	case codes.Unknown:
-		return http.StatusInternalServerError
-	case codes.InvalidArgument:
-		return http.StatusBadRequest
-	case codes.DeadlineExceeded:
-		return http.StatusGatewayTimeout
-	case codes.NotFound:
-		return http.StatusNotFound
-	case codes.AlreadyExists:
-		return http.StatusConflict
-	case codes.PermissionDenied:
-		return http.StatusForbidden
-	case codes.Unauthenticated:
-		return http.StatusUnauthorized
-	case codes.ResourceExhausted:
-		return http.StatusTooManyRequests
-	case codes.FailedPrecondition:
-		return http.StatusBadRequest
-	case codes.Aborted:
-		return http.StatusConflict
-	case codes.OutOfRange:
-		return http.StatusBadRequest
-	case codes.Unimplemented:
-		return http.StatusNotImplemented
-	case codes.Internal:
-		return http.StatusInternalServerError
-	case codes.Unavailable:
-		return http.StatusServiceUnavailable
-	case codes.DataLoss:
-		return http.StatusInternalServerError
+func (c *TableConverter) FromGRPCCode(code codes.Code) int {
+	if v, ok := c.fromGRPCCodes[code]; ok {
+		return v
 	}
-	return http.StatusInternalServerError
+	return c.unknownHTTPStatus
 }
 
 // ToGRPCCode converts an HTTP error code into the corresponding gRPC response status.
@@ -107,3 +183,11 @@ func ToGRPCCode(code int) codes.Code {
 func FromGRPCCode(code codes.Code) int {
 	return DefaultConverter.FromGRPCCode(code)
 }
+
+// converterOrDefault returns c if not nil, otherwise DefaultConverter.
+func converterOrDefault(c Converter) Converter {
+	if c == nil {
+		return DefaultConverter
+	}
+	return c
+}
@@ -0,0 +1,128 @@
+package errorx_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+
+	"github.com/things-go/dyn/errorx"
+)
+
+func Test_TableConverter(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		c := errorx.NewTableConverter()
+		for status, code := range map[int]codes.Code{
+			http.StatusOK:                           codes.OK,
+			http.StatusBadRequest:                   codes.InvalidArgument,
+			http.StatusUnauthorized:                 codes.Unauthenticated,
+			http.StatusForbidden:                    codes.PermissionDenied,
+			http.StatusNotFound:                     codes.NotFound,
+			http.StatusMethodNotAllowed:             codes.Unimplemented,
+			http.StatusRequestTimeout:               codes.DeadlineExceeded,
+			http.StatusConflict:                     codes.Aborted,
+			http.StatusPreconditionFailed:           codes.FailedPrecondition,
+			http.StatusRequestedRangeNotSatisfiable: codes.OutOfRange,
+			http.StatusUnprocessableEntity:          codes.InvalidArgument,
+			http.StatusTooManyRequests:              codes.ResourceExhausted,
+			errorx.ClientClosed:                     codes.Canceled,
+			http.StatusInternalServerError:          codes.Internal,
+			http.StatusNotImplemented:               codes.Unimplemented,
+			http.StatusBadGateway:                   codes.Unavailable,
+			http.StatusServiceUnavailable:           codes.Unavailable,
+			http.StatusGatewayTimeout:               codes.DeadlineExceeded,
+			http.StatusTeapot:                       codes.Unknown,
+		} {
+			require.Equal(t, code, c.ToGRPCCode(status), status)
+		}
+		for code, status := range map[codes.Code]int{
+			codes.OK:                 http.StatusOK,
+			codes.Canceled:           errorx.ClientClosed,
+			codes.Unknown:            http.StatusInternalServerError,
+			codes.InvalidArgument:    http.StatusBadRequest,
+			codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+			codes.NotFound:           http.StatusNotFound,
+			codes.AlreadyExists:      http.StatusConflict,
+			codes.PermissionDenied:   http.StatusForbidden,
+			codes.Unauthenticated:    http.StatusUnauthorized,
+			codes.ResourceExhausted:  http.StatusTooManyRequests,
+			codes.FailedPrecondition: http.StatusBadRequest,
+			codes.Aborted:            http.StatusConflict,
+			codes.OutOfRange:         http.StatusBadRequest,
+			codes.Unimplemented:      http.StatusNotImplemented,
+			codes.Internal:           http.StatusInternalServerError,
+			codes.Unavailable:        http.StatusServiceUnavailable,
+			codes.DataLoss:           http.StatusInternalServerError,
+			codes.Code(100):          http.StatusInternalServerError,
+		} {
+			require.Equal(t, status, c.FromGRPCCode(code), code)
+		}
+	})
+	t.Run("round-trip", func(t *testing.T) {
+		c := errorx.NewTableConverter()
+		// collapse into the same code.
+		collapsed := map[codes.Code]codes.Code{
+			codes.Unknown:            codes.Internal,
+			codes.AlreadyExists:      codes.Aborted,
+			codes.FailedPrecondition: codes.InvalidArgument,
+			codes.OutOfRange:         codes.InvalidArgument,
+			codes.DataLoss:           codes.Internal,
+		}
+		for code := codes.OK; code <= codes.Unauthenticated; code++ {
+			want, ok := collapsed[code]
+			if !ok {
+				want = code
+			}
+			require.Equal(t, want, c.ToGRPCCode(c.FromGRPCCode(code)), code)
+		}
+		for _, status := range []int{
+			http.StatusBadRequest,
+			http.StatusTooManyRequests,
+			errorx.ClientClosed,
+			http.StatusServiceUnavailable,
+		} {
+			require.Equal(t, status, c.FromGRPCCode(c.ToGRPCCode(status)), status)
+		}
+	})
+	t.Run("opt in", func(t *testing.T) {
+		c := errorx.NewTableConverter(
+			errorx.WithMapping(http.StatusPreconditionFailed, codes.FailedPrecondition),
+			errorx.WithMapping(http.StatusRequestedRangeNotSatisfiable, codes.OutOfRange),
+		)
+		require.Equal(t, http.StatusPreconditionFailed, c.FromGRPCCode(codes.FailedPrecondition))
+		require.Equal(t, http.StatusRequestedRangeNotSatisfiable, c.FromGRPCCode(codes.OutOfRange))
+		// the default converter is untouched.
+		require.Equal(t, http.StatusBadRequest, errorx.FromGRPCCode(codes.FailedPrecondition))
+	})
+	t.Run("override", func(t *testing.T) {
+		c := errorx.NewTableConverter(
+			errorx.WithMapping(http.StatusUnprocessableEntity, codes.FailedPrecondition),
+			errorx.WithToGRPCCode(http.StatusConflict, codes.AlreadyExists),
+			errorx.WithUnknownGRPCCode(codes.Internal),
+			errorx.WithUnknownHTTPStatus(http.StatusBadGateway),
+		)
+		require.Equal(t, codes.FailedPrecondition, c.ToGRPCCode(http.StatusUnprocessableEntity))
+		require.Equal(t, http.StatusUnprocessableEntity, c.FromGRPCCode(codes.FailedPrecondition))
+		require.Equal(t, codes.AlreadyExists, c.ToGRPCCode(http.StatusConflict))
+		require.Equal(t, codes.Internal, c.ToGRPCCode(http.StatusTeapot))
+		require.Equal(t, http.StatusBadGateway, c.FromGRPCCode(codes.Code(100)))
+		// the default converter is untouched.
+		require.Equal(t, codes.Aborted, errorx.ToGRPCCode(http.StatusConflict))
+	})
+	t.Run("inject", func(t *testing.T) {
+		c := errorx.NewTableConverter(errorx.WithMapping(http.StatusConflict, codes.AlreadyExists))
+		err := errorx.NewConflict()
+		require.Equal(t, codes.Aborted, err.GRPCCode())
+		require.Equal(t, codes.AlreadyExists, err.GRPCCodeWith(c))
+		require.Equal(t, codes.AlreadyExists, err.GRPCStatusWith(c).Code())
+
+		err = errorx.New(1001, "用户已存在", errorx.WithGRPCCode(codes.AlreadyExists))
+		require.Equal(t, http.StatusConflict, err.HTTPStatusWith(c))
+		require.Equal(t, http.StatusConflict, err.HTTPStatusWith(nil))
+
+		gotErr := errorx.FromGRPCStatusWith(err.GRPCStatusWith(c), c)
+		require.Equal(t, int32(1001), gotErr.Code())
+		require.Equal(t, codes.AlreadyExists, gotErr.GRPCCode())
+	})
+}
@@ -4,14 +4,22 @@ package errno
 
 import (
 	errors "github.com/things-go/dyn/errorx"
+	"google.golang.org/grpc/codes"
 )
 
 // ErrTimeout 1000: 操作超时
 func ErrTimeout(opts ...errors.Option) *errors.Error {
-	return errors.New(int32(Timeout), Timeout.String(), opts...)
+	return errors.New(int32(Timeout), Timeout.String(), append([]errors.Option{errors.WithReason("TIMEOUT"), errors.WithStatus(504), errors.WithGRPCCode(codes.DeadlineExceeded)}, opts...)...)
 }
 
 // ErrUserNotExist 1001: 用户不存在
 func ErrUserNotExist(opts ...errors.Option) *errors.Error {
-	return errors.New(int32(UserNotExist), UserNotExist.String(), opts...)
+	return errors.New(int32(UserNotExist), UserNotExist.String(), append([]errors.Option{errors.WithReason("USER_NOT_EXIST")}, opts...)...)
+}
+
+func init() {
+	errors.MustRegister("github.com/things-go/dyn/example/errno.BizError",
+		ErrTimeout(),
+		ErrUserNotExist(),
+	)
 }
@@ -1,9 +1,10 @@
-//go:generate errno-gen -t BizError
+//go:generate errno-gen -t BizError --register
 package errno
 
 type BizError int // 业务错误
 
 const (
+	// #[errno(status="504", grpc_code="DEADLINE_EXCEEDED")]
 	Timeout      BizError = 1000 + iota // 操作超时
 	UserNotExist                        // 用户不存在
 )
@@ -24,19 +24,20 @@ type ErrorReason int32
 
 const (
 	// xx
-	ErrorReason_unspecified ErrorReason = 0    // 未定义
-	ErrorReason_bad_request ErrorReason = 1000 // 用户名或密码错误
+	ErrorReason_unspecified ErrorReason = 0 // 未定义
+	// #[errno(status="400", grpc_code="INVALID_ARGUMENT")]
+	ErrorReason_bad_request ErrorReason = 2000 // 用户名或密码错误
 )
 
 // Enum value maps for ErrorReason.
 var (
 	ErrorReason_name = map[int32]string{
 		0:    "unspecified",
-		1000: "bad_request",
+		2000: "bad_request",
 	}
 	ErrorReason_value = map[string]int32{
 		"unspecified": 0,
-		"bad_request": 1000,
+		"bad_request": 2000,
 	}
 )
 
@@ -74,7 +75,7 @@ var file_errnop_errno_proto_rawDesc = []byte{
 	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x65, 0x72, 0x72, 0x6e, 0x6f, 0x70, 0x2a, 0x30, 0x0a, 0x0b,
 	0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x0f, 0x0a, 0x0b, 0x75,
 	0x6e, 0x73, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x65, 0x64, 0x10, 0x00, 0x12, 0x10, 0x0a, 0x0b,
-	0x62, 0x61, 0x64, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x10, 0xe8, 0x07, 0x42, 0x29,
+	0x62, 0x61, 0x64, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x10, 0xd0, 0x0f, 0x42, 0x29,
 	0x5a, 0x27, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x74, 0x68, 0x69,
 	0x6e, 0x67, 0x73, 0x2d, 0x67, 0x6f, 0x2f, 0x64, 0x79, 0x6e, 0x2f, 0x65, 0x78, 0x61, 0x6d, 0x70,
 	0x6c, 0x65, 0x2f, 0x65, 0x72, 0x72, 0x6e, 0x6f, 0x70, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
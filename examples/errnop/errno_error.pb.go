@@ -9,6 +9,7 @@ package errnop
 import (
 	fmt "fmt"
 	errorx "github.com/things-go/dyn/errorx"
+	codes "google.golang.org/grpc/codes"
 )
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -21,11 +22,17 @@ func IsUnspecified(err error) bool {
 	return errorx.EqualCode(err, 0)
 }
 func ErrUnspecified() *errorx.Error {
-	return errorx.New(0, "未定义")
+	return errorx.New(0, "未定义", errorx.WithReason("UNSPECIFIED"))
 }
 func IsBadRequest(err error) bool {
-	return errorx.EqualCode(err, 1000)
+	return errorx.EqualCode(err, 2000)
 }
 func ErrBadRequest() *errorx.Error {
-	return errorx.New(1000, "用户名或密码错误")
+	return errorx.New(2000, "用户名或密码错误", errorx.WithReason("BAD_REQUEST"), errorx.WithStatus(400), errorx.WithGRPCCode(codes.InvalidArgument))
+}
+
+func init() {
+	errorx.MustRegister("github.com/things-go/dyn/example/errnop.ErrorReason",
+		ErrBadRequest(),
+	)
 }
@@ -0,0 +1,264 @@
+// Code generated by protoc-gen-dyn-gin. DO NOT EDIT.
+// versions:
+//   - protoc-gen-dyn-gin v1.0.0
+//   - protoc             v5.28.1
+// source: bookstore/bookstore.proto
+
+package bookstore
+
+import (
+	context "context"
+	errors "errors"
+	fmt "fmt"
+	errorx "github.com/things-go/dyn/errorx"
+	transport "github.com/things-go/dyn/transport"
+	nethttp "github.com/things-go/dyn/transport/nethttp"
+	http "net/http"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible.
+var _ = errors.New
+var _ = context.TODO
+var _ = http.NewServeMux
+var _ = transport.Chain
+var _ = nethttp.FromCarrier
+
+// BookstoreHTTPServer The bookstore service definition.
+type BookstoreHTTPServer interface {
+	// ListShelves List the shelves
+	ListShelves(context.Context, *ListShelvesRequest) (*ListShelvesReply, error)
+	// CreateShelf Create a shelf
+	CreateShelf(context.Context, *CreateShelfRequest) (*Shelf, error)
+	// UpdateShelf Update a shelf
+	UpdateShelf(context.Context, *UpdateShelfRequest) (*Shelf, error)
+	// Deprecated: Do not use.
+	// DeleteShelf Delete a shelf
+	DeleteShelf(context.Context, *DeleteShelfRequest) (*DeleteShelfReply, error)
+	// CreateBook Create a book on the shelf
+	CreateBook(context.Context, *CreateBookRequest) (*Book, error)
+	// GetBook Get a book on the shelf
+	GetBook(context.Context, *GetBookRequest) (*GetBookReply, error)
+}
+
+// RegisterBookstoreHTTPServer register the http handlers on the ServeMux,
+// the service methods are invoked through the middlewares.
+func RegisterBookstoreHTTPServer(mux *http.ServeMux, srv BookstoreHTTPServer, ms ...transport.Middleware) {
+	m := transport.Chain(ms...)
+	mux.Handle("GET /v1/shelves", _Bookstore_ListShelves0_HTTP_Handler(srv, m))
+	mux.Handle("POST /v1/shelves", _Bookstore_CreateShelf0_HTTP_Handler(srv, m))
+	mux.Handle("PUT /v1/shelves/{id}", _Bookstore_UpdateShelf0_HTTP_Handler(srv, m))
+	mux.Handle("DELETE /v1/shelves/{id}", _Bookstore_DeleteShelf0_HTTP_Handler(srv, m))
+	mux.Handle("POST /v1/shelves/{shelf_id}/books", _Bookstore_CreateBook0_HTTP_Handler(srv, m))
+	mux.Handle("GET /v1/shelves/{shelf_id}/books/{id}", _Bookstore_GetBook0_HTTP_Handler(srv, m))
+}
+
+func _Bookstore_ListShelves0_HTTP_Handler(srv BookstoreHTTPServer, m transport.Middleware) http.Handler {
+	h := m(func(ctx context.Context, req any) (any, error) {
+		in, ok := req.(*ListShelvesRequest)
+		if !ok {
+			return nil, errorx.NewInternalServer(errorx.WithCause(fmt.Errorf("unexpected request type %T, want *ListShelvesRequest", req)))
+		}
+		return srv.ListShelves(ctx, in)
+	})
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var err error
+		var req ListShelvesRequest
+
+		carrier := nethttp.FromCarrier(r.Context())
+		if err = carrier.ShouldBindQuery(r, &req); err != nil {
+			carrier.Error(w, r, err)
+			return
+		}
+		out, err := h(r.Context(), &req)
+		if err != nil {
+			carrier.Error(w, r, err)
+			return
+		}
+		reply, ok := out.(*ListShelvesReply)
+		if !ok {
+			carrier.Error(w, r, fmt.Errorf("unexpected reply type %T, want *ListShelvesReply", out))
+			return
+		}
+		carrier.Render(w, r, reply)
+	})
+}
+
+func _Bookstore_CreateShelf0_HTTP_Handler(srv BookstoreHTTPServer, m transport.Middleware) http.Handler {
+	h := m(func(ctx context.Context, req any) (any, error) {
+		in, ok := req.(*CreateShelfRequest)
+		if !ok {
+			return nil, errorx.NewInternalServer(errorx.WithCause(fmt.Errorf("unexpected request type %T, want *CreateShelfRequest", req)))
+		}
+		return srv.CreateShelf(ctx, in)
+	})
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var err error
+		var req CreateShelfRequest
+
+		carrier := nethttp.FromCarrier(r.Context())
+		shouldBind := func(req *CreateShelfRequest) error {
+			if err := carrier.BindQuery(r, req); err != nil {
+				return err
+			}
+			if err := carrier.Bind(r, &req.Shelf); err != nil {
+				return err
+			}
+			return carrier.Validate(r.Context(), req)
+		}
+
+		if err = shouldBind(&req); err != nil {
+			carrier.Error(w, r, err)
+			return
+		}
+		out, err := h(r.Context(), &req)
+		if err != nil {
+			carrier.Error(w, r, err)
+			return
+		}
+		reply, ok := out.(*Shelf)
+		if !ok {
+			carrier.Error(w, r, fmt.Errorf("unexpected reply type %T, want *Shelf", out))
+			return
+		}
+		carrier.Render(w, r, reply)
+	})
+}
+
+func _Bookstore_UpdateShelf0_HTTP_Handler(srv BookstoreHTTPServer, m transport.Middleware) http.Handler {
+	h := m(func(ctx context.Context, req any) (any, error) {
+		in, ok := req.(*UpdateShelfRequest)
+		if !ok {
+			return nil, errorx.NewInternalServer(errorx.WithCause(fmt.Errorf("unexpected request type %T, want *UpdateShelfRequest", req)))
+		}
+		return srv.UpdateShelf(ctx, in)
+	})
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var err error
+		var req UpdateShelfRequest
+
+		carrier := nethttp.FromCarrier(r.Context())
+		if err = carrier.ShouldBindQueryBodyUri(r, &req); err != nil {
+			carrier.Error(w, r, err)
+			return
+		}
+		out, err := h(r.Context(), &req)
+		if err != nil {
+			carrier.Error(w, r, err)
+			return
+		}
+		reply, ok := out.(*Shelf)
+		if !ok {
+			carrier.Error(w, r, fmt.Errorf("unexpected reply type %T, want *Shelf", out))
+			return
+		}
+		carrier.Render(w, r, reply)
+	})
+}
+
+// Deprecated: Do not use.
+func _Bookstore_DeleteShelf0_HTTP_Handler(srv BookstoreHTTPServer, m transport.Middleware) http.Handler {
+	h := m(func(ctx context.Context, req any) (any, error) {
+		in, ok := req.(*DeleteShelfRequest)
+		if !ok {
+			return nil, errorx.NewInternalServer(errorx.WithCause(fmt.Errorf("unexpected request type %T, want *DeleteShelfRequest", req)))
+		}
+		return srv.DeleteShelf(ctx, in)
+	})
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var err error
+		var req DeleteShelfRequest
+
+		carrier := nethttp.FromCarrier(r.Context())
+		if err = carrier.ShouldBindQueryUri(r, &req); err != nil {
+			carrier.Error(w, r, err)
+			return
+		}
+		out, err := h(r.Context(), &req)
+		if err != nil {
+			carrier.Error(w, r, err)
+			return
+		}
+		reply, ok := out.(*DeleteShelfReply)
+		if !ok {
+			carrier.Error(w, r, fmt.Errorf("unexpected reply type %T, want *DeleteShelfReply", out))
+			return
+		}
+		carrier.Render(w, r, reply)
+	})
+}
+
+func _Bookstore_CreateBook0_HTTP_Handler(srv BookstoreHTTPServer, m transport.Middleware) http.Handler {
+	h := m(func(ctx context.Context, req any) (any, error) {
+		in, ok := req.(*CreateBookRequest)
+		if !ok {
+			return nil, errorx.NewInternalServer(errorx.WithCause(fmt.Errorf("unexpected request type %T, want *CreateBookRequest", req)))
+		}
+		return srv.CreateBook(ctx, in)
+	})
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var err error
+		var req CreateBookRequest
+
+		carrier := nethttp.FromCarrier(r.Context())
+		shouldBind := func(req *CreateBookRequest) error {
+			if err := carrier.BindQuery(r, req); err != nil {
+				return err
+			}
+			if err := carrier.Bind(r, &req.Book); err != nil {
+				return err
+			}
+			if err := carrier.BindUri(r, req); err != nil {
+				return err
+			}
+			return carrier.Validate(r.Context(), req)
+		}
+
+		if err = shouldBind(&req); err != nil {
+			carrier.Error(w, r, err)
+			return
+		}
+		out, err := h(r.Context(), &req)
+		if err != nil {
+			carrier.Error(w, r, err)
+			return
+		}
+		reply, ok := out.(*Book)
+		if !ok {
+			carrier.Error(w, r, fmt.Errorf("unexpected reply type %T, want *Book", out))
+			return
+		}
+		carrier.Render(w, r, reply)
+	})
+}
+
+func _Bookstore_GetBook0_HTTP_Handler(srv BookstoreHTTPServer, m transport.Middleware) http.Handler {
+	h := m(func(ctx context.Context, req any) (any, error) {
+		in, ok := req.(*GetBookRequest)
+		if !ok {
+			return nil, errorx.NewInternalServer(errorx.WithCause(fmt.Errorf("unexpected request type %T, want *GetBookRequest", req)))
+		}
+		return srv.GetBook(ctx, in)
+	})
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var err error
+		var req GetBookRequest
+
+		carrier := nethttp.FromCarrier(r.Context())
+		if err = carrier.ShouldBindQueryUri(r, &req); err != nil {
+			carrier.Error(w, r, err)
+			return
+		}
+		out, err := h(r.Context(), &req)
+		if err != nil {
+			carrier.Error(w, r, err)
+			return
+		}
+		reply, ok := out.(*GetBookReply)
+		if !ok {
+			carrier.Error(w, r, fmt.Errorf("unexpected reply type %T, want *GetBookReply", out))
+			return
+		}
+		carrier.Render(w, r, reply.Book)
+	})
+}
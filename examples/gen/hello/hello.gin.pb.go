@@ -9,7 +9,10 @@ package hello
 import (
 	context "context"
 	errors "errors"
+	fmt "fmt"
 	gin "github.com/gin-gonic/gin"
+	errorx "github.com/things-go/dyn/errorx"
+	transport "github.com/things-go/dyn/transport"
 	http "github.com/things-go/dyn/transport/http"
 )
 
@@ -18,6 +21,7 @@ import (
 var _ = errors.New
 var _ = context.TODO
 var _ = gin.New
+var _ = transport.Chain
 var _ = http.FromCarrier
 
 const __Greeter_Metadata_Service = "The greeting service definition."
@@ -33,50 +37,75 @@ type GreeterHTTPServer interface {
 	GetHello(context.Context, *GetHelloRequest) (*GetHelloReply, error)
 }
 
-func RegisterGreeterHTTPServer(g *gin.RouterGroup, srv GreeterHTTPServer) {
+// RegisterGreeterHTTPServer register the http handlers,
+// the service methods are invoked through the middlewares.
+func RegisterGreeterHTTPServer(g *gin.RouterGroup, srv GreeterHTTPServer, ms ...transport.Middleware) {
+	m := transport.Chain(ms...)
 	r := g.Group("")
 	{
-		r.POST("/v1/hello", http.MetadataInterceptor(http.Metadata{Service: __Greeter_Metadata_Service, Method: "Sends a hello, 多一行"}), _Greeter_SayHello0_HTTP_Handler(srv))
-		r.GET("/v1/hello/:id", http.MetadataInterceptor(http.Metadata{Service: __Greeter_Metadata_Service, Method: "Get a hello"}), _Greeter_GetHello0_HTTP_Handler(srv))
+		r.POST("/v1/hello", http.MetadataInterceptor(http.Metadata{Service: __Greeter_Metadata_Service, Method: "Sends a hello, 多一行"}), _Greeter_SayHello0_HTTP_Handler(srv, m))
+		r.GET("/v1/hello/:id", http.MetadataInterceptor(http.Metadata{Service: __Greeter_Metadata_Service, Method: "Get a hello"}), _Greeter_GetHello0_HTTP_Handler(srv, m))
 	}
 }
 
-func _Greeter_SayHello0_HTTP_Handler(srv GreeterHTTPServer) gin.HandlerFunc {
+func _Greeter_SayHello0_HTTP_Handler(srv GreeterHTTPServer, m transport.Middleware) gin.HandlerFunc {
+	h := m(func(ctx context.Context, req any) (any, error) {
+		in, ok := req.(*HelloRequest)
+		if !ok {
+			return nil, errorx.NewInternalServer(errorx.WithCause(fmt.Errorf("unexpected request type %T, want *HelloRequest", req)))
+		}
+		return srv.SayHello(ctx, in)
+	})
 	return func(c *gin.Context) {
 		var err error
 		var req HelloRequest
-		var reply *HelloReply
 
 		carrier := http.FromCarrier(c.Request.Context())
 		if err = carrier.ShouldBindQueryBody(c, &req); err != nil {
 			carrier.Error(c, err)
 			return
 		}
-		reply, err = srv.SayHello(c.Request.Context(), &req)
+		out, err := h(c.Request.Context(), &req)
 		if err != nil {
 			carrier.Error(c, err)
 			return
 		}
+		reply, ok := out.(*HelloReply)
+		if !ok {
+			carrier.Error(c, fmt.Errorf("unexpected reply type %T, want *HelloReply", out))
+			return
+		}
 		carrier.Render(c, reply)
 	}
 }
 
-func _Greeter_GetHello0_HTTP_Handler(srv GreeterHTTPServer) gin.HandlerFunc {
+func _Greeter_GetHello0_HTTP_Handler(srv GreeterHTTPServer, m transport.Middleware) gin.HandlerFunc {
+	h := m(func(ctx context.Context, req any) (any, error) {
+		in, ok := req.(*GetHelloRequest)
+		if !ok {
+			return nil, errorx.NewInternalServer(errorx.WithCause(fmt.Errorf("unexpected request type %T, want *GetHelloRequest", req)))
+		}
+		return srv.GetHello(ctx, in)
+	})
 	return func(c *gin.Context) {
 		var err error
 		var req GetHelloRequest
-		var reply *GetHelloReply
 
 		carrier := http.FromCarrier(c.Request.Context())
 		if err = carrier.ShouldBindQueryUri(c, &req); err != nil {
 			carrier.Error(c, err)
 			return
 		}
-		reply, err = srv.GetHello(c.Request.Context(), &req)
+		out, err := h(c.Request.Context(), &req)
 		if err != nil {
 			carrier.Error(c, err)
 			return
 		}
+		reply, ok := out.(*GetHelloReply)
+		if !ok {
+			carrier.Error(c, fmt.Errorf("unexpected reply type %T, want *GetHelloReply", out))
+			return
+		}
 		carrier.Render(c, reply)
 	}
 }
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"sync"
+
+	"github.com/things-go/dyn/carry"
+	"github.com/things-go/dyn/errorx"
+	"github.com/things-go/dyn/example/gen/bookstore"
+	"github.com/things-go/dyn/transport/nethttp"
+)
+
+func main() {
+	mux := http.NewServeMux()
+	mux.Handle("GET /errors", errorx.DefaultRegistry)
+	bookstore.RegisterBookstoreHTTPServer(mux, NewBookstore())
+
+	var h http.Handler = mux
+	h = nethttp.TransportInterceptor()(h)
+	// the error body is the problem details, same as the openapi document.
+	h = nethttp.CarrierInterceptor(carry.NewCarryStd(carry.WithTransformError(carry.NewProblemTransformer())))(h)
+	log.Fatal(http.ListenAndServe(":9090", h))
+}
+
+var _ bookstore.BookstoreHTTPServer = (*Bookstore)(nil)
+
+type Bookstore struct {
+	mu      sync.Mutex
+	shelves map[int64]*bookstore.Shelf
+	books   map[int64]map[int64]*bookstore.Book
+	nextId  int64
+}
+
+func NewBookstore() *Bookstore {
+	return &Bookstore{
+		shelves: make(map[int64]*bookstore.Shelf),
+		books:   make(map[int64]map[int64]*bookstore.Book),
+	}
+}
+
+// ListShelves implements bookstore.BookstoreHTTPServer.
+func (b *Bookstore) ListShelves(_ context.Context, _ *bookstore.ListShelvesRequest) (*bookstore.ListShelvesReply, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	shelves := make([]*bookstore.Shelf, 0, len(b.shelves))
+	for _, v := range b.shelves {
+		shelves = append(shelves, v)
+	}
+	return &bookstore.ListShelvesReply{
+		Shelves: shelves,
+		Total:   int64(len(shelves)),
+	}, nil
+}
+
+// CreateShelf implements bookstore.BookstoreHTTPServer.
+func (b *Bookstore) CreateShelf(_ context.Context, req *bookstore.CreateShelfRequest) (*bookstore.Shelf, error) {
+	if req.Shelf == nil {
+		return nil, errorx.New(http.StatusBadRequest, "the shelf is required")
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.nextId++
+	shelf := &bookstore.Shelf{Id: b.nextId, Theme: req.Shelf.Theme}
+	b.shelves[shelf.Id] = shelf
+	return shelf, nil
+}
+
+// UpdateShelf implements bookstore.BookstoreHTTPServer.
+func (b *Bookstore) UpdateShelf(_ context.Context, req *bookstore.UpdateShelfRequest) (*bookstore.Shelf, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	shelf, ok := b.shelves[req.Id]
+	if !ok {
+		return nil, errorx.New(http.StatusNotFound, "shelf not found")
+	}
+	shelf.Theme = req.Theme
+	return shelf, nil
+}
+
+// DeleteShelf implements bookstore.BookstoreHTTPServer.
+func (b *Bookstore) DeleteShelf(_ context.Context, req *bookstore.DeleteShelfRequest) (*bookstore.DeleteShelfReply, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.shelves, req.Id)
+	delete(b.books, req.Id)
+	return &bookstore.DeleteShelfReply{}, nil
+}
+
+// CreateBook implements bookstore.BookstoreHTTPServer.
+func (b *Bookstore) CreateBook(_ context.Context, req *bookstore.CreateBookRequest) (*bookstore.Book, error) {
+	if req.Book == nil {
+		return nil, errorx.New(http.StatusBadRequest, "the book is required")
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.shelves[req.ShelfId]; !ok {
+		return nil, errorx.New(http.StatusNotFound, "shelf not found")
+	}
+	b.nextId++
+	book := &bookstore.Book{Id: b.nextId, Title: req.Book.Title, Genre: req.Book.Genre}
+	if b.books[req.ShelfId] == nil {
+		b.books[req.ShelfId] = make(map[int64]*bookstore.Book)
+	}
+	b.books[req.ShelfId][book.Id] = book
+	return book, nil
+}
+
+// GetBook implements bookstore.BookstoreHTTPServer.
+func (b *Bookstore) GetBook(_ context.Context, req *bookstore.GetBookRequest) (*bookstore.GetBookReply, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	book, ok := b.books[req.ShelfId][req.Id]
+	if !ok {
+		return nil, errorx.New(http.StatusNotFound, "book not found")
+	}
+	return &bookstore.GetBookReply{Book: book}, nil
+}
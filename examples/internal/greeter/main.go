@@ -7,6 +7,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/things-go/dyn/carry"
+	"github.com/things-go/dyn/errorx"
 	"github.com/things-go/dyn/example/gen/hello"
 	transportHttp "github.com/things-go/dyn/transport/http"
 )
@@ -23,6 +24,7 @@ func main() {
 		}()
 		c.Next()
 	})
+	g.GET("/errors", gin.WrapH(errorx.DefaultRegistry))
 	group := g.Group("/api")
 	hello.RegisterGreeterHTTPServer(group, new(Greeter))
 	g.Run(":9090")
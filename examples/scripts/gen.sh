@@ -8,7 +8,7 @@ proto_dir=proto
 out_dir=${project_dir}/gen # 生成代码路径
 third_party_dir=${project_dir}/third_party
 
-protos=$(find ${project_dir}/${proto_dir} -type f -name '*.proto')
+protos=$(find ${project_dir}/${proto_dir}/hello -type f -name '*.proto')
 protoc \
   -I ${project_dir}/${proto_dir} \
   -I ${third_party_dir} \
@@ -25,6 +25,21 @@ protoc \
   --dyn-resty_opt paths=source_relative \
   $protos
 
+# the net/http ServeMux(Go 1.22+) mode
+protostd=$(find ${project_dir}/${proto_dir}/bookstore -type f -name '*.proto')
+protoc \
+  -I ${project_dir}/${proto_dir} \
+  -I ${third_party_dir} \
+  -I ${project_dir} \
+  --go_out=${out_dir} \
+  --go_opt paths=source_relative \
+  --dyn-gin_out ${out_dir} \
+  --dyn-gin_opt paths=source_relative \
+  --dyn-gin_opt mode=std \
+  --dyn-openapi_out ${out_dir} \
+  --dyn-openapi_opt paths=source_relative \
+  $protostd
+
 out_errno_dir=${project_dir} # 生成代码路径
 protoerrno=$(find ${project_dir}/errnop -type f -name '*.proto')
 protoc \
@@ -35,4 +50,5 @@ protoc \
   --go_opt paths=source_relative \
   --dyn-errno_out ${out_errno_dir} \
   --dyn-errno_opt paths=source_relative \
+  --dyn-errno_opt register=true \
   $protoerrno
@@ -0,0 +1,184 @@
+package transport
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+)
+
+// Header keys of the client ip forwarded by the proxies,
+// the gRPC metadata keys are the lower case ones.
+const (
+	HeaderForwarded     = "Forwarded"
+	HeaderXForwardedFor = "X-Forwarded-For"
+	HeaderXRealIp       = "X-Real-Ip"
+)
+
+// ClientIpResolver resolves the client ip of the request, it is shared by the http and gRPC transport,
+// so `Transporter.ClientIp()` returns the same port-less address regardless of transport.
+//
+// the forwarded headers are only honoured when the peer is a trusted proxy,
+// they are checked in order: `Forwarded`(RFC 7239), `X-Forwarded-For`, `X-Real-Ip`.
+// the forwarded chain is walked from right to left, the trusted proxies are skipped,
+// the first untrusted address is the client ip.
+//
+// the zero value trusts no proxy, the peer address is always the client ip.
+type ClientIpResolver struct {
+	trustedProxies []netip.Prefix
+}
+
+// NewClientIpResolver new client ip resolver with the trusted proxies,
+// the trusted proxy is a CIDR like "10.0.0.0/8" or an ip like "127.0.0.1".
+func NewClientIpResolver(trustedProxies ...string) (*ClientIpResolver, error) {
+	r := &ClientIpResolver{
+		trustedProxies: make([]netip.Prefix, 0, len(trustedProxies)),
+	}
+	for _, s := range trustedProxies {
+		var prefix netip.Prefix
+		if strings.Contains(s, "/") {
+			p, err := netip.ParsePrefix(s)
+			if err != nil {
+				return nil, fmt.Errorf("transport: invalid trusted proxy %q, %w", s, err)
+			}
+			prefix = p.Masked()
+		} else {
+			addr, err := netip.ParseAddr(s)
+			if err != nil {
+				return nil, fmt.Errorf("transport: invalid trusted proxy %q, %w", s, err)
+			}
+			addr = addr.Unmap()
+			prefix = netip.PrefixFrom(addr, addr.BitLen())
+		}
+		r.trustedProxies = append(r.trustedProxies, prefix)
+	}
+	return r, nil
+}
+
+// MustNewClientIpResolver like NewClientIpResolver, but panic if any trusted proxy is invalid.
+func MustNewClientIpResolver(trustedProxies ...string) *ClientIpResolver {
+	r, err := NewClientIpResolver(trustedProxies...)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Resolve returns the port-less client ip.
+// remoteAddr is the peer address, with or without the port.
+// values returns all the values of the header key, like `http.Header.Values` or `metadata.MD.Get`.
+func (r *ClientIpResolver) Resolve(remoteAddr string, values func(key string) []string) string {
+	remote, ok := parseAddr(remoteAddr)
+	if !ok {
+		return stripPort(remoteAddr)
+	}
+	if r == nil || !r.isTrusted(remote) || values == nil {
+		return remote.String()
+	}
+	if ip, ok := r.resolveChain(parseForwarded(values(HeaderForwarded))); ok {
+		return ip
+	}
+	if ip, ok := r.resolveChain(splitList(values(HeaderXForwardedFor))); ok {
+		return ip
+	}
+	if vs := values(HeaderXRealIp); len(vs) > 0 {
+		if addr, ok := parseAddr(strings.TrimSpace(vs[0])); ok {
+			return addr.String()
+		}
+	}
+	return remote.String()
+}
+
+// resolveChain walks the forwarded chain from right to left, returns the first untrusted address,
+// or the leftmost one if all are trusted. it fails if the chain is empty or any visited address is invalid.
+func (r *ClientIpResolver) resolveChain(chain []string) (string, bool) {
+	if len(chain) == 0 {
+		return "", false
+	}
+	var addr netip.Addr
+	for i := len(chain) - 1; i >= 0; i-- {
+		var ok bool
+		addr, ok = parseAddr(chain[i])
+		if !ok {
+			return "", false
+		}
+		if !r.isTrusted(addr) {
+			break
+		}
+	}
+	return addr.String(), true
+}
+
+func (r *ClientIpResolver) isTrusted(addr netip.Addr) bool {
+	for _, p := range r.trustedProxies {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseAddr parses the address with or without the port, like "1.2.3.4", "1.2.3.4:80",
+// "::1", "[::1]" and "[::1]:80", the IPv4-mapped IPv6 address is unmapped and the zone is dropped.
+func parseAddr(s string) (netip.Addr, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return netip.Addr{}, false
+	}
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		addr, err = netip.ParseAddr(strings.TrimSuffix(strings.TrimPrefix(stripPort(s), "["), "]"))
+		if err != nil {
+			return netip.Addr{}, false
+		}
+	}
+	return addr.Unmap().WithZone(""), true
+}
+
+// stripPort returns the host of the address if it has a port, otherwise the address itself.
+func stripPort(s string) string {
+	if host, _, err := net.SplitHostPort(s); err == nil {
+		return host
+	}
+	return s
+}
+
+// splitList splits the comma separated header values.
+func splitList(values []string) []string {
+	var list []string
+	for _, v := range values {
+		for _, s := range strings.Split(v, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				list = append(list, s)
+			}
+		}
+	}
+	return list
+}
+
+// parseForwarded returns the `for` parameter of each forwarded element, see RFC 7239.
+//
+//	Forwarded: for=192.0.2.43, for="[2001:db8:cafe::17]:4711";proto=https
+//
+// the element without the `for` parameter is kept as an empty string,
+// so the chain is failed rather than skipping an unknown hop.
+func parseForwarded(values []string) []string {
+	var list []string
+	for _, v := range values {
+		for _, elem := range strings.Split(v, ",") {
+			if strings.TrimSpace(elem) == "" {
+				continue
+			}
+			node := ""
+			for _, pair := range strings.Split(elem, ";") {
+				k, v, ok := strings.Cut(strings.TrimSpace(pair), "=")
+				if ok && strings.EqualFold(strings.TrimSpace(k), "for") {
+					node = strings.Trim(strings.TrimSpace(v), `"`)
+					break
+				}
+			}
+			list = append(list, node)
+		}
+	}
+	return list
+}
@@ -0,0 +1,93 @@
+package transport_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/things-go/dyn/transport"
+)
+
+func Test_ClientIpResolver(t *testing.T) {
+	_, err := transport.NewClientIpResolver("10.0.0.0/33")
+	require.Error(t, err)
+	_, err = transport.NewClientIpResolver("localhost")
+	require.Error(t, err)
+
+	r := transport.MustNewClientIpResolver("10.0.0.0/8", "127.0.0.1", "::1")
+	for _, tt := range []struct {
+		name       string
+		remoteAddr string
+		header     http.Header
+		want       string
+	}{
+		{"no port", "1.2.3.4", nil, "1.2.3.4"},
+		{"with port", "1.2.3.4:8080", nil, "1.2.3.4"},
+		{"ipv6 with port", "[2001:db8::1]:8080", nil, "2001:db8::1"},
+		{"ipv4-mapped", "[::ffff:1.2.3.4]:8080", nil, "1.2.3.4"},
+		{"not ip", "bufconn", nil, "bufconn"},
+		{
+			"untrusted peer ignores forwarded",
+			"1.2.3.4:8080",
+			http.Header{"X-Forwarded-For": {"5.6.7.8"}},
+			"1.2.3.4",
+		},
+		{
+			"x-forwarded-for skips trusted",
+			"127.0.0.1:8080",
+			http.Header{"X-Forwarded-For": {"5.6.7.8, 9.9.9.9", "10.0.0.2"}},
+			"9.9.9.9",
+		},
+		{
+			"x-forwarded-for all trusted",
+			"127.0.0.1:8080",
+			http.Header{"X-Forwarded-For": {"10.0.0.3, 10.0.0.2"}},
+			"10.0.0.3",
+		},
+		{
+			"forwarded first",
+			"[::1]:8080",
+			http.Header{
+				"Forwarded":       {`for=192.0.2.60;proto=http, for="[2001:db8:cafe::17]:4711"`},
+				"X-Forwarded-For": {"5.6.7.8"},
+			},
+			"2001:db8:cafe::17",
+		},
+		{
+			"forwarded unknown falls back",
+			"127.0.0.1:8080",
+			http.Header{
+				"Forwarded":       {"for=unknown"},
+				"X-Forwarded-For": {"5.6.7.8"},
+			},
+			"5.6.7.8",
+		},
+		{
+			"x-real-ip",
+			"127.0.0.1:8080",
+			http.Header{"X-Real-Ip": {"5.6.7.8"}},
+			"5.6.7.8",
+		},
+		{
+			"invalid forwarded falls back to peer",
+			"127.0.0.1:8080",
+			http.Header{"X-Forwarded-For": {"garbage"}},
+			"127.0.0.1",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, r.Resolve(tt.remoteAddr, tt.header.Values))
+		})
+	}
+
+	t.Run("grpc metadata", func(t *testing.T) {
+		md := metadata.Pairs("x-forwarded-for", "5.6.7.8, 10.0.0.2")
+		require.Equal(t, "5.6.7.8", r.Resolve("10.0.0.1:50051", md.Get))
+	})
+	t.Run("zero value trusts no proxy", func(t *testing.T) {
+		var zero *transport.ClientIpResolver
+		require.Equal(t, "1.2.3.4", zero.Resolve("1.2.3.4:80", http.Header{"X-Real-Ip": {"5.6.7.8"}}.Values))
+	})
+}
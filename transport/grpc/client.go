@@ -0,0 +1,160 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/things-go/dyn/errorx"
+	"github.com/things-go/dyn/transport"
+)
+
+// ClientTransporterCallOption is a grpc.CallOption which receives the client Transporter of the call,
+// it works with `UnaryClientInterceptor` and `StreamClientInterceptor`, see `ClientTransporter`.
+type ClientTransporterCallOption struct {
+	grpc.EmptyCallOption
+	Transporter *transport.Transporter
+}
+
+// ClientTransporter returns a grpc.CallOption which stores the client Transporter of the call into tr,
+// so the caller reads the received `ResponseHeader()` and `ResponseTrailer()`, like `grpc.Header`.
+// unary: both are available once the call returns.
+// stream: the response header is available once it is received, the response trailer once the stream is finished.
+func ClientTransporter(tr *transport.Transporter) grpc.CallOption {
+	return ClientTransporterCallOption{Transporter: tr}
+}
+
+// newClientContext returns a new Context that carries the client Transporter,
+// the outgoing metadata is the request header, it is attached to the call,
+// so the changes of the request header take effect.
+// the Transporter is stored into the `ClientTransporterCallOption` of the call options, if any.
+func newClientContext(ctx context.Context, method string, callOpts []grpc.CallOption) (context.Context, metadata.MD, metadata.MD) {
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if ok {
+		md = md.Copy()
+	} else {
+		md = metadata.MD{}
+	}
+	responseHeader := metadata.MD{}
+	responseTrailer := metadata.MD{}
+	tr := &Transport{
+		method,
+		"",
+		header(md),
+		header(responseHeader),
+		header(responseTrailer),
+	}
+	for _, opt := range callOpts {
+		if o, ok := opt.(ClientTransporterCallOption); ok && o.Transporter != nil {
+			*o.Transporter = tr
+		}
+	}
+	ctx = metadata.NewOutgoingContext(ctx, md)
+	ctx = transport.WithValueClientTransporter(ctx, tr)
+	return ctx, responseHeader, responseTrailer
+}
+
+// clientError convert the returned error into `*errorx.Error`, nil and io.EOF keep unchanged.
+func (o *errorOptions) clientError(err error) error {
+	if err == nil || errors.Is(err, io.EOF) {
+		return err
+	}
+	if e := errorx.FromErrorWith(err, o.converter); e != nil {
+		return e
+	}
+	return nil
+}
+
+// UnaryClientInterceptor is a gRPC unary client interceptor,
+// it places the client Transporter in context, see `transport.FromClientTransporter`,
+// the caller receives it via the `ClientTransporter` call option,
+// and converts the returned status into `*errorx.Error`.
+func UnaryClientInterceptor(opts ...ErrorOption) grpc.UnaryClientInterceptor {
+	o := newErrorOptions(opts...)
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, callOpts ...grpc.CallOption) error {
+		ctx, responseHeader, responseTrailer := newClientContext(ctx, method, callOpts)
+		md, trailer := metadata.MD{}, metadata.MD{}
+		err := invoker(ctx, method, req, reply, cc, append(callOpts, grpc.Header(&md), grpc.Trailer(&trailer))...)
+		for k, v := range md {
+			responseHeader[k] = v
+		}
+		for k, v := range trailer {
+			responseTrailer[k] = v
+		}
+		return o.clientError(err)
+	}
+}
+
+// wrappedClientStream fill the response header once it is received,
+// the response trailer once the stream is finished,
+// and converts the returned status into `*errorx.Error`.
+type wrappedClientStream struct {
+	grpc.ClientStream
+	o               *errorOptions
+	once            sync.Once
+	responseHeader  metadata.MD
+	responseTrailer metadata.MD
+}
+
+func (w *wrappedClientStream) fillHeader() {
+	w.once.Do(func() {
+		if md, err := w.ClientStream.Header(); err == nil {
+			for k, v := range md {
+				w.responseHeader[k] = v
+			}
+		}
+	})
+}
+
+func (w *wrappedClientStream) Header() (metadata.MD, error) {
+	md, err := w.ClientStream.Header()
+	if err == nil {
+		w.fillHeader()
+	}
+	return md, w.o.clientError(err)
+}
+
+func (w *wrappedClientStream) SendMsg(m any) error {
+	return w.o.clientError(w.ClientStream.SendMsg(m))
+}
+
+func (w *wrappedClientStream) RecvMsg(m any) error {
+	err := w.ClientStream.RecvMsg(m)
+	w.fillHeader()
+	if err != nil {
+		// the stream is finished, the trailer is available.
+		for k, v := range w.ClientStream.Trailer() {
+			w.responseTrailer[k] = v
+		}
+	}
+	return w.o.clientError(err)
+}
+
+func (w *wrappedClientStream) CloseSend() error {
+	return w.o.clientError(w.ClientStream.CloseSend())
+}
+
+// StreamClientInterceptor is a gRPC stream client interceptor,
+// it places the client Transporter in context, see `transport.FromClientTransporter`,
+// the caller receives it via the `ClientTransporter` call option,
+// and converts the returned status into `*errorx.Error`.
+func StreamClientInterceptor(opts ...ErrorOption) grpc.StreamClientInterceptor {
+	o := newErrorOptions(opts...)
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, callOpts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx, responseHeader, responseTrailer := newClientContext(ctx, method, callOpts)
+		cs, err := streamer(ctx, desc, cc, method, callOpts...)
+		if err != nil {
+			return nil, o.clientError(err)
+		}
+		return &wrappedClientStream{
+			ClientStream:    cs,
+			o:               o,
+			responseHeader:  responseHeader,
+			responseTrailer: responseTrailer,
+		}, nil
+	}
+}
@@ -0,0 +1,75 @@
+package grpc_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/things-go/dyn/errorx"
+	"github.com/things-go/dyn/transport"
+	dgrpc "github.com/things-go/dyn/transport/grpc"
+)
+
+func Test_ClientInterceptor(t *testing.T) {
+	srv := &healthServer{
+		check: func(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+			md, _ := metadata.FromIncomingContext(ctx)
+			_ = grpc.SetHeader(ctx, metadata.Pairs("x-echo", md.Get("x-token")[0]))
+			_ = grpc.SetTrailer(ctx, metadata.Pairs("x-trailer", "done"))
+			if req.Service == "unknown" {
+				return nil, errorx.NewNotFound(errorx.WithReason("SERVICE_NOT_FOUND"))
+			}
+			return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+		},
+		watch: func(req *healthpb.HealthCheckRequest, ss healthpb.Health_WatchServer) error {
+			_ = ss.SendHeader(metadata.Pairs("x-echo", "stream"))
+			ss.SetTrailer(metadata.Pairs("x-trailer", "done"))
+			_ = ss.Send(&healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING})
+			return errorx.NewServiceUnavailable()
+		},
+	}
+	client := newTestClient(t, srv,
+		[]grpc.ServerOption{
+			grpc.ChainUnaryInterceptor(dgrpc.UnaryServerErrorInterceptor()),
+			grpc.ChainStreamInterceptor(dgrpc.StreamServerErrorInterceptor()),
+		},
+		grpc.WithChainUnaryInterceptor(dgrpc.UnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(dgrpc.StreamClientInterceptor()),
+	)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-token", "abc")
+
+	t.Run("unary", func(t *testing.T) {
+		var tr transport.Transporter
+		_, err := client.Check(ctx, &healthpb.HealthCheckRequest{}, dgrpc.ClientTransporter(&tr))
+		require.NoError(t, err)
+		require.Equal(t, transport.GRPC, tr.Kind())
+		require.Equal(t, healthpb.Health_Check_FullMethodName, tr.FullPath())
+		require.Equal(t, "abc", tr.RequestHeader().Get("x-token"))
+		require.Equal(t, "abc", tr.ResponseHeader().Get("x-echo"))
+		require.Equal(t, "done", tr.ResponseTrailer().Get("x-trailer"))
+	})
+	t.Run("unary error", func(t *testing.T) {
+		var tr transport.Transporter
+		_, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: "unknown"}, dgrpc.ClientTransporter(&tr))
+		require.True(t, errorx.EqualCode(err, 404))
+		require.Equal(t, "SERVICE_NOT_FOUND", errorx.Parse(err).Reason())
+		require.Equal(t, "done", tr.ResponseTrailer().Get("x-trailer"))
+	})
+	t.Run("stream", func(t *testing.T) {
+		var tr transport.Transporter
+		stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{}, dgrpc.ClientTransporter(&tr))
+		require.NoError(t, err)
+		reply, err := stream.Recv()
+		require.NoError(t, err)
+		require.Equal(t, healthpb.HealthCheckResponse_SERVING, reply.Status)
+		require.Equal(t, "stream", tr.ResponseHeader().Get("x-echo"))
+
+		_, err = stream.Recv()
+		require.True(t, errorx.EqualCode(err, 503))
+		require.Equal(t, "done", tr.ResponseTrailer().Get("x-trailer"))
+	})
+}
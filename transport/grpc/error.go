@@ -0,0 +1,81 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/things-go/dyn/errorx"
+)
+
+// ErrorOption error interceptor option
+type ErrorOption func(*errorOptions)
+
+type errorOptions struct {
+	converter errorx.Converter
+	catalog   *errorx.Catalog
+}
+
+// WithConverter convert the status code of the error with the converter,
+// default errorx.DefaultConverter.
+func WithConverter(c errorx.Converter) ErrorOption {
+	return func(o *errorOptions) {
+		o.converter = c
+	}
+}
+
+// WithCatalog localize the error message with the catalog,
+// the locale is selected from the `accept-language` metadata,
+// it requires the Transporter in context, see `UnaryServerInterceptor` and `StreamServerInterceptor`.
+func WithCatalog(c *errorx.Catalog) ErrorOption {
+	return func(o *errorOptions) {
+		o.catalog = c
+	}
+}
+
+func newErrorOptions(opts ...ErrorOption) *errorOptions {
+	o := &errorOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// statusError normalise the error into the gRPC status error,
+// the error is parsed via `errorx.FromError`, so the gRPC status error returned by
+// the handler is kept, any other error become `errorx.NewInternalServer`.
+// the internal cause is hidden unless `deploy.IsTesting()`, see `errorx.Error.Public`.
+// the request id in context is carried by `errdetails.RequestInfo`, see `transport.FromRequestId`.
+func (o *errorOptions) statusError(ctx context.Context, err error) error {
+	e := errorx.FromErrorWith(err, o.converter)
+	if o.catalog != nil {
+		e = o.catalog.Localize(ctx, e)
+	}
+	return e.WithContextRequestId(ctx).GRPCStatusWith(o.converter).Err()
+}
+
+// UnaryServerErrorInterceptor is a gRPC unary server interceptor,
+// it normalises the returned error through errorx, same as the error rendered by the http carrier.
+func UnaryServerErrorInterceptor(opts ...ErrorOption) grpc.UnaryServerInterceptor {
+	o := newErrorOptions(opts...)
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		reply, err := handler(ctx, req)
+		if err != nil {
+			return nil, o.statusError(ctx, err)
+		}
+		return reply, nil
+	}
+}
+
+// StreamServerErrorInterceptor is a gRPC stream server interceptor,
+// it normalises the returned error through errorx, same as the error rendered by the http carrier.
+func StreamServerErrorInterceptor(opts ...ErrorOption) grpc.StreamServerInterceptor {
+	o := newErrorOptions(opts...)
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		err := handler(srv, ss)
+		if err != nil {
+			return o.statusError(ss.Context(), err)
+		}
+		return nil
+	}
+}
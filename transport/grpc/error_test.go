@@ -0,0 +1,127 @@
+package grpc_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/things-go/dyn/errorx"
+	dgrpc "github.com/things-go/dyn/transport/grpc"
+)
+
+func Test_ServerErrorInterceptor(t *testing.T) {
+	catalog := errorx.NewCatalog("zh")
+	require.NoError(t, catalog.LoadFS(errorx.BuiltinLocales, "locales/*.json"))
+	converter := errorx.NewTableConverter(errorx.WithToGRPCCode(409, codes.Aborted))
+
+	var checkErr error
+	srv := &healthServer{
+		check: func(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+			return nil, checkErr
+		},
+		watch: func(req *healthpb.HealthCheckRequest, ss healthpb.Health_WatchServer) error {
+			_ = ss.Send(&healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING})
+			return errorx.NewServiceUnavailable(errorx.WithReason("MAINTAINING"))
+		},
+	}
+	client := newTestClient(t, srv,
+		[]grpc.ServerOption{
+			grpc.ChainUnaryInterceptor(
+				dgrpc.UnaryServerInterceptor(),
+				dgrpc.UnaryServerErrorInterceptor(dgrpc.WithConverter(converter), dgrpc.WithCatalog(catalog)),
+			),
+			grpc.ChainStreamInterceptor(
+				dgrpc.StreamServerInterceptor(),
+				dgrpc.StreamServerErrorInterceptor(),
+			),
+		},
+	)
+
+	for _, tt := range []struct {
+		name        string
+		err         error
+		lang        string
+		wantCode    codes.Code
+		wantMessage string
+		wantReason  string
+		wantStatus  string
+	}{
+		{
+			name:        "errorx",
+			err:         errorx.NewNotFound(errorx.WithReason("SERVICE_NOT_FOUND"), errorx.WithMessage("service not found")),
+			wantCode:    codes.NotFound,
+			wantMessage: "service not found",
+			wantReason:  "SERVICE_NOT_FOUND",
+			wantStatus:  "404",
+		},
+		{
+			name:        "unknown error becomes internal server error",
+			err:         errors.New("sql: connection refused"),
+			wantCode:    codes.Internal,
+			wantMessage: "服务器错误",
+			wantStatus:  "500",
+		},
+		{
+			name:        "status error is kept",
+			err:         status.Error(codes.PermissionDenied, "denied"),
+			wantCode:    codes.PermissionDenied,
+			wantMessage: "denied",
+			wantStatus:  "403",
+		},
+		{
+			name:        "converter",
+			err:         errorx.NewConflict(),
+			lang:        "zh",
+			wantCode:    codes.Aborted,
+			wantMessage: "资源冲突",
+			wantStatus:  "409",
+		},
+		{
+			name:        "catalog",
+			err:         errorx.NewNotFound(),
+			lang:        "en-US,en;q=0.9",
+			wantCode:    codes.NotFound,
+			wantMessage: "Not found, the resource does not exist",
+			wantStatus:  "404",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			checkErr = tt.err
+			ctx := context.Background()
+			if tt.lang != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, "accept-language", tt.lang)
+			}
+			_, err := client.Check(ctx, &healthpb.HealthCheckRequest{})
+			st, ok := status.FromError(err)
+			require.True(t, ok)
+			require.Equal(t, tt.wantCode, st.Code())
+			require.Equal(t, tt.wantMessage, st.Message())
+			var info *errdetails.ErrorInfo
+			for _, detail := range st.Details() {
+				if v, ok := detail.(*errdetails.ErrorInfo); ok {
+					info = v
+				}
+			}
+			require.NotNil(t, info)
+			require.Equal(t, tt.wantReason, info.Reason)
+			require.Equal(t, tt.wantStatus, info.Metadata["errorx-status"])
+		})
+	}
+	t.Run("stream", func(t *testing.T) {
+		stream, err := client.Watch(context.Background(), &healthpb.HealthCheckRequest{})
+		require.NoError(t, err)
+		_, err = stream.Recv()
+		require.NoError(t, err)
+		_, err = stream.Recv()
+		require.Equal(t, codes.Unavailable, status.Code(err))
+		require.Equal(t, "MAINTAINING", errorx.FromError(err).Reason())
+	})
+}
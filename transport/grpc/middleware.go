@@ -0,0 +1,30 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/things-go/dyn/transport"
+)
+
+// UnaryServerMiddleware adapts the transport-agnostic middlewares to a gRPC unary server interceptor,
+// use it after `UnaryServerInterceptor`, so the Transporter is available in the middlewares.
+func UnaryServerMiddleware(ms ...transport.Middleware) grpc.UnaryServerInterceptor {
+	m := transport.Chain(ms...)
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return m(transport.Handler(handler))(ctx, req)
+	}
+}
+
+// UnaryClientMiddleware adapts the transport-agnostic middlewares to a gRPC unary client interceptor,
+// use it after `UnaryClientInterceptor`, so the client Transporter is available in the middlewares.
+func UnaryClientMiddleware(ms ...transport.Middleware) grpc.UnaryClientInterceptor {
+	m := transport.Chain(ms...)
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		_, err := m(func(ctx context.Context, req any) (any, error) {
+			return reply, invoker(ctx, method, req, reply, cc, opts...)
+		})(ctx, req)
+		return err
+	}
+}
@@ -0,0 +1,85 @@
+package grpc
+
+import (
+	"context"
+	"log/slog"
+
+	"google.golang.org/grpc"
+
+	"github.com/things-go/dyn/errorx"
+	"github.com/things-go/dyn/transport"
+)
+
+// RecoveryOption recovery interceptor option
+type RecoveryOption func(*recoveryOptions)
+
+type recoveryOptions struct {
+	logger *slog.Logger
+}
+
+// WithRecoveryLogger set the logger which logs the panic, default slog.Default().
+func WithRecoveryLogger(l *slog.Logger) RecoveryOption {
+	return func(o *recoveryOptions) {
+		o.logger = l
+	}
+}
+
+func newRecoveryOptions(opts ...RecoveryOption) *recoveryOptions {
+	o := &recoveryOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// recover convert the recovered panic value into `errorx.NewInternalServer` and log it.
+func (o *recoveryOptions) recover(ctx context.Context, fullMethod string, r any) error {
+	err := errorx.FromPanic(r)
+	attrs := []any{
+		slog.Any("error", err),
+		slog.String("method", fullMethod),
+	}
+	if tr, ok := transport.FromTransporter(ctx); ok {
+		attrs = append(attrs, slog.Group("transport",
+			slog.String("kind", tr.Kind().String()),
+			slog.String("path", tr.FullPath()),
+			slog.String("client_ip", tr.ClientIp()),
+		))
+	}
+	logger := o.logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	logger.ErrorContext(ctx, "panic recovered", attrs...)
+	return err
+}
+
+// UnaryServerRecoveryInterceptor is a gRPC unary server interceptor,
+// it recovers the panic into `errorx.NewInternalServer` with the stack captured and logs it.
+// the panic value is hidden from the client unless `deploy.IsTesting()`, see `errorx.Error.GRPCStatus`.
+func UnaryServerRecoveryInterceptor(opts ...RecoveryOption) grpc.UnaryServerInterceptor {
+	o := newRecoveryOptions(opts...)
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (reply any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				reply, err = nil, o.recover(ctx, info.FullMethod, r)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerRecoveryInterceptor is a gRPC stream server interceptor,
+// it recovers the panic into `errorx.NewInternalServer` with the stack captured and logs it.
+// the panic value is hidden from the client unless `deploy.IsTesting()`, see `errorx.Error.GRPCStatus`.
+func StreamServerRecoveryInterceptor(opts ...RecoveryOption) grpc.StreamServerInterceptor {
+	o := newRecoveryOptions(opts...)
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = o.recover(ss.Context(), info.FullMethod, r)
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
@@ -0,0 +1,59 @@
+package grpc_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	dgrpc "github.com/things-go/dyn/transport/grpc"
+)
+
+func Test_ServerRecoveryInterceptor(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := slog.New(slog.NewJSONHandler(buf, nil))
+	srv := &healthServer{
+		check: func(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+			panic("boom")
+		},
+		watch: func(req *healthpb.HealthCheckRequest, ss healthpb.Health_WatchServer) error {
+			panic("boom")
+		},
+	}
+	client := newTestClient(t, srv,
+		[]grpc.ServerOption{
+			grpc.ChainUnaryInterceptor(dgrpc.UnaryServerInterceptor(), dgrpc.UnaryServerRecoveryInterceptor(dgrpc.WithRecoveryLogger(logger))),
+			grpc.ChainStreamInterceptor(dgrpc.StreamServerInterceptor(), dgrpc.StreamServerRecoveryInterceptor(dgrpc.WithRecoveryLogger(logger))),
+		},
+	)
+
+	t.Run("unary", func(t *testing.T) {
+		buf.Reset()
+		_, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+		st, ok := status.FromError(err)
+		require.True(t, ok)
+		require.Equal(t, codes.Internal, st.Code())
+		// the panic value is hidden from the client.
+		require.Equal(t, "服务器错误", st.Message())
+		require.NotContains(t, st.String(), "boom")
+
+		require.Contains(t, buf.String(), `"msg":"panic recovered"`)
+		require.Contains(t, buf.String(), `"method":"`+healthpb.Health_Check_FullMethodName+`"`)
+		require.Contains(t, buf.String(), `"transport":{"kind":"grpc"`)
+		require.Contains(t, buf.String(), "boom")
+	})
+	t.Run("stream", func(t *testing.T) {
+		buf.Reset()
+		stream, err := client.Watch(context.Background(), &healthpb.HealthCheckRequest{})
+		require.NoError(t, err)
+		_, err = stream.Recv()
+		require.Equal(t, codes.Internal, status.Code(err))
+		require.Contains(t, buf.String(), `"method":"`+healthpb.Health_Watch_FullMethodName+`"`)
+	})
+}
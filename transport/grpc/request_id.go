@@ -0,0 +1,109 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/things-go/dyn/transport"
+)
+
+// metadataKeyRequestId the gRPC metadata key of the request id.
+var metadataKeyRequestId = strings.ToLower(transport.HeaderRequestId)
+
+// RequestIdOption request id interceptor option
+type RequestIdOption func(*requestIdOptions)
+
+type requestIdOptions struct {
+	generator func() string
+}
+
+// WithRequestIdGenerator set the request id generator, default transport.NewRequestId.
+func WithRequestIdGenerator(f func() string) RequestIdOption {
+	return func(o *requestIdOptions) {
+		o.generator = f
+	}
+}
+
+func newRequestIdOptions(opts ...RequestIdOption) *requestIdOptions {
+	o := &requestIdOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// requestId reads the request id from the incoming metadata, generates one if missing or invalid,
+// then stores it in context, the echoed response header is returned if the Transporter is not in context,
+// otherwise it is set to the `Transporter.ResponseHeader()`.
+func (o *requestIdOptions) requestId(ctx context.Context) (context.Context, metadata.MD) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	id := transport.RequestIdFromHeader(header(md), o.generator)
+	ctx = transport.WithValueRequestId(ctx, id)
+	if tr, ok := transport.FromTransporter(ctx); ok {
+		tr.ResponseHeader().Set(metadataKeyRequestId, id)
+		return ctx, nil
+	}
+	return ctx, metadata.Pairs(metadataKeyRequestId, id)
+}
+
+// UnaryServerRequestIdInterceptor is a gRPC unary server interceptor,
+// it reads the request id from the `x-request-id` metadata, generates one if missing or invalid,
+// then stores it in context, see `transport.FromRequestId`, and echoes it in the response header.
+// use it after `UnaryServerInterceptor`, so the request id is echoed in the `Transporter.ResponseHeader()`.
+func UnaryServerRequestIdInterceptor(opts ...RequestIdOption) grpc.UnaryServerInterceptor {
+	o := newRequestIdOptions(opts...)
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, md := o.requestId(ctx)
+		if md != nil {
+			_ = grpc.SetHeader(ctx, md)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerRequestIdInterceptor is a gRPC stream server interceptor,
+// it reads the request id from the `x-request-id` metadata, generates one if missing or invalid,
+// then stores it in context, see `transport.FromRequestId`, and echoes it in the response header.
+// use it after `StreamServerInterceptor`, so the request id is echoed in the `Transporter.ResponseHeader()`.
+func StreamServerRequestIdInterceptor(opts ...RequestIdOption) grpc.StreamServerInterceptor {
+	o := newRequestIdOptions(opts...)
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, md := o.requestId(ss.Context())
+		if md != nil {
+			_ = ss.SetHeader(md)
+		}
+		return handler(srv, NewWrappedStream(ctx, ss))
+	}
+}
+
+// outgoingRequestId forwards the request id in context to the outgoing metadata,
+// the request id already in the outgoing metadata keeps unchanged.
+func outgoingRequestId(ctx context.Context) context.Context {
+	id, ok := transport.FromRequestId(ctx)
+	if !ok {
+		return ctx
+	}
+	if md, ok := metadata.FromOutgoingContext(ctx); ok && len(md.Get(metadataKeyRequestId)) > 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, metadataKeyRequestId, id)
+}
+
+// UnaryClientRequestIdInterceptor is a gRPC unary client interceptor,
+// it forwards the request id in context to the outgoing `x-request-id` metadata.
+func UnaryClientRequestIdInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(outgoingRequestId(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientRequestIdInterceptor is a gRPC stream client interceptor,
+// it forwards the request id in context to the outgoing `x-request-id` metadata.
+func StreamClientRequestIdInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(outgoingRequestId(ctx), desc, cc, method, opts...)
+	}
+}
@@ -0,0 +1,105 @@
+package grpc_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/things-go/dyn/errorx"
+	"github.com/things-go/dyn/transport"
+	dgrpc "github.com/things-go/dyn/transport/grpc"
+)
+
+func Test_RequestIdInterceptor(t *testing.T) {
+	generator := dgrpc.WithRequestIdGenerator(func() string { return "gen-1" })
+	var gotId string
+	srv := &healthServer{
+		check: func(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+			gotId, _ = transport.FromRequestId(ctx)
+			if req.Service == "unknown" {
+				return nil, errorx.NewNotFound()
+			}
+			return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+		},
+		watch: func(req *healthpb.HealthCheckRequest, ss healthpb.Health_WatchServer) error {
+			gotId, _ = transport.FromRequestId(ss.Context())
+			return ss.Send(&healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING})
+		},
+	}
+	withTransporter := newTestClient(t, srv,
+		[]grpc.ServerOption{
+			grpc.ChainUnaryInterceptor(
+				dgrpc.UnaryServerInterceptor(),
+				dgrpc.UnaryServerRequestIdInterceptor(generator),
+				dgrpc.UnaryServerErrorInterceptor(),
+			),
+			grpc.ChainStreamInterceptor(
+				dgrpc.StreamServerInterceptor(),
+				dgrpc.StreamServerRequestIdInterceptor(generator),
+			),
+		},
+		grpc.WithChainUnaryInterceptor(dgrpc.UnaryClientRequestIdInterceptor()),
+		grpc.WithChainStreamInterceptor(dgrpc.StreamClientRequestIdInterceptor()),
+	)
+	withoutTransporter := newTestClient(t, srv,
+		[]grpc.ServerOption{
+			grpc.ChainUnaryInterceptor(dgrpc.UnaryServerRequestIdInterceptor(generator)),
+			grpc.ChainStreamInterceptor(dgrpc.StreamServerRequestIdInterceptor(generator)),
+		},
+		grpc.WithChainUnaryInterceptor(dgrpc.UnaryClientRequestIdInterceptor()),
+		grpc.WithChainStreamInterceptor(dgrpc.StreamClientRequestIdInterceptor()),
+	)
+
+	for _, tt := range []struct {
+		name   string
+		ctx    context.Context
+		wantId string
+	}{
+		{"incoming", metadata.AppendToOutgoingContext(context.Background(), "x-request-id", "req-1"), "req-1"},
+		{"generate if missing", context.Background(), "gen-1"},
+		{"generate if invalid", metadata.AppendToOutgoingContext(context.Background(), "x-request-id", "req 1"), "gen-1"},
+		{"generate if too long", metadata.AppendToOutgoingContext(context.Background(), "x-request-id", strings.Repeat("a", 129)), "gen-1"},
+		{"forward the context", transport.WithValueRequestId(context.Background(), "fwd-1"), "fwd-1"},
+		{
+			"keep the outgoing metadata",
+			metadata.AppendToOutgoingContext(transport.WithValueRequestId(context.Background(), "fwd-1"), "x-request-id", "req-1"),
+			"req-1",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, client := range map[string]healthpb.HealthClient{
+				"with transporter":    withTransporter,
+				"without transporter": withoutTransporter,
+			} {
+				gotId = ""
+				var header metadata.MD
+				_, err := client.Check(tt.ctx, &healthpb.HealthCheckRequest{}, grpc.Header(&header))
+				require.NoError(t, err, name)
+				require.Equal(t, tt.wantId, gotId, name)
+				require.Equal(t, []string{tt.wantId}, header.Get("x-request-id"), name)
+
+				gotId = ""
+				stream, err := client.Watch(tt.ctx, &healthpb.HealthCheckRequest{})
+				require.NoError(t, err, name)
+				_, err = stream.Recv()
+				require.NoError(t, err, name)
+				header, err = stream.Header()
+				require.NoError(t, err, name)
+				require.Equal(t, tt.wantId, gotId, name)
+				require.Equal(t, []string{tt.wantId}, header.Get("x-request-id"), name)
+			}
+		})
+	}
+	t.Run("error carries the request id", func(t *testing.T) {
+		ctx := metadata.AppendToOutgoingContext(context.Background(), "x-request-id", "req-1")
+		_, err := withTransporter.Check(ctx, &healthpb.HealthCheckRequest{Service: "unknown"})
+		gotErr := errorx.FromError(err)
+		require.Equal(t, int32(404), gotErr.Code())
+		require.Equal(t, "req-1", gotErr.RequestId())
+	})
+}
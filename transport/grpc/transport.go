@@ -2,6 +2,7 @@ package grpc
 
 import (
 	"context"
+	"sync"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
@@ -14,10 +15,11 @@ var _ transport.Transporter = (*Transport)(nil)
 
 // Transport is a gRPC transport.
 type Transport struct {
-	fullMethod     string
-	clientIp       string
-	requestHeader  header
-	responseHeader header
+	fullMethod      string
+	clientIp        string
+	requestHeader   header
+	responseHeader  header
+	responseTrailer header
 }
 
 // Kind returns the transport kind.
@@ -45,6 +47,11 @@ func (tr *Transport) ResponseHeader() transport.Header {
 	return tr.responseHeader
 }
 
+// ResponseTrailer returns the reply trailer.
+func (tr *Transport) ResponseTrailer() transport.Header {
+	return tr.responseTrailer
+}
+
 type header metadata.MD
 
 // Len returns the number of items in header.
@@ -89,26 +96,60 @@ func (h header) Keys() []string {
 // Clone returns a copy of h or nil if h is nil.
 func (h header) Clone() transport.Header { return transport.Header(header(metadata.MD(h).Copy())) }
 
+// TransportOption transport interceptor option
+type TransportOption func(*transportOptions)
+
+type transportOptions struct {
+	clientIpResolver *transport.ClientIpResolver
+}
+
+// WithClientIpResolver set the client ip resolver, default trusts no proxy,
+// the peer address is always the client ip.
+func WithClientIpResolver(r *transport.ClientIpResolver) TransportOption {
+	return func(o *transportOptions) {
+		o.clientIpResolver = r
+	}
+}
+
+func newTransportOptions(opts ...TransportOption) *transportOptions {
+	o := &transportOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// clientIp resolves the port-less client ip from the peer and the forwarded metadata.
+func (o *transportOptions) clientIp(ctx context.Context, md metadata.MD) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	return o.clientIpResolver.Resolve(p.Addr.String(), md.Get)
+}
+
 // UnaryServerInterceptor is a gRPC unary server interceptor
-func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+func UnaryServerInterceptor(opts ...TransportOption) grpc.UnaryServerInterceptor {
+	o := newTransportOptions(opts...)
 	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
 		md, _ := metadata.FromIncomingContext(ctx)
-		p, _ := peer.FromContext(ctx)
-		clientIp := ""
-		if p != nil {
-			clientIp = p.Addr.String()
-		}
+		clientIp := o.clientIp(ctx, md)
 		responseHeader := metadata.MD{}
+		responseTrailer := metadata.MD{}
 		ctx = transport.WithValueTransporter(ctx, &Transport{
 			info.FullMethod,
 			clientIp,
 			header(md),
 			header(responseHeader),
+			header(responseTrailer),
 		})
 		reply, err := handler(ctx, req)
 		if len(responseHeader) > 0 {
 			_ = grpc.SetHeader(ctx, responseHeader)
 		}
+		if len(responseTrailer) > 0 {
+			_ = grpc.SetTrailer(ctx, responseTrailer)
+		}
 		return reply, err
 	}
 }
@@ -130,27 +171,65 @@ func (w *wrappedStream) Context() context.Context {
 	return w.ctx
 }
 
-// StreamServerInterceptor is a gRPC stream server interceptor
-func StreamServerInterceptor() grpc.StreamServerInterceptor {
+// transportStream flushes the response header of the Transporter on the first SendHeader or SendMsg,
+// because the header of a streaming RPC is sent before the first message,
+// the changes of the response header after flushed are dropped.
+type transportStream struct {
+	grpc.ServerStream
+	ctx            context.Context
+	once           sync.Once
+	responseHeader metadata.MD
+}
+
+func (s *transportStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *transportStream) flushHeader() {
+	s.once.Do(func() {
+		if len(s.responseHeader) > 0 {
+			_ = s.ServerStream.SetHeader(s.responseHeader)
+		}
+	})
+}
+
+func (s *transportStream) SendHeader(md metadata.MD) error {
+	s.flushHeader()
+	return s.ServerStream.SendHeader(md)
+}
+
+func (s *transportStream) SendMsg(m any) error {
+	s.flushHeader()
+	return s.ServerStream.SendMsg(m)
+}
+
+// StreamServerInterceptor is a gRPC stream server interceptor,
+// the response header is sent on the first SendHeader or SendMsg,
+// the response trailer is sent when the handler returns.
+func StreamServerInterceptor(opts ...TransportOption) grpc.StreamServerInterceptor {
+	o := newTransportOptions(opts...)
 	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
 		md, _ := metadata.FromIncomingContext(ss.Context())
-		p, _ := peer.FromContext(ss.Context())
-		clientIp := ""
-		if p != nil {
-			clientIp = p.Addr.String()
-		}
+		clientIp := o.clientIp(ss.Context(), md)
 		responseHeader := metadata.MD{}
+		responseTrailer := metadata.MD{}
 		ctx := transport.WithValueTransporter(ss.Context(), &Transport{
 			info.FullMethod,
 			clientIp,
 			header(md),
 			header(responseHeader),
+			header(responseTrailer),
 		})
 
-		ws := NewWrappedStream(ctx, ss)
-		err := handler(srv, ws)
-		if len(responseHeader) > 0 {
-			_ = grpc.SetHeader(ctx, responseHeader)
+		ts := &transportStream{
+			ServerStream:   ss,
+			ctx:            ctx,
+			responseHeader: responseHeader,
+		}
+		err := handler(srv, ts)
+		ts.flushHeader()
+		if len(responseTrailer) > 0 {
+			ss.SetTrailer(responseTrailer)
 		}
 		return err
 	}
@@ -0,0 +1,108 @@
+package grpc_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/things-go/dyn/transport"
+	dgrpc "github.com/things-go/dyn/transport/grpc"
+)
+
+func Test_ServerInterceptor(t *testing.T) {
+	srv := &healthServer{
+		check: func(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+			tr, ok := transport.FromTransporter(ctx)
+			require.True(t, ok)
+			require.Equal(t, transport.GRPC, tr.Kind())
+			require.Equal(t, healthpb.Health_Check_FullMethodName, tr.FullPath())
+			tr.ResponseHeader().Set("x-echo", tr.RequestHeader().Get("x-token"))
+			tr.ResponseTrailer().Set("x-trailer", "done")
+			return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+		},
+		watch: func(req *healthpb.HealthCheckRequest, ss healthpb.Health_WatchServer) error {
+			tr, ok := transport.FromTransporter(ss.Context())
+			require.True(t, ok)
+			require.Equal(t, healthpb.Health_Watch_FullMethodName, tr.FullPath())
+			tr.ResponseHeader().Set("x-echo", tr.RequestHeader().Get("x-token"))
+			if req.Service == "empty" {
+				// the header is flushed even though no message is sent.
+				tr.ResponseTrailer().Set("x-trailer", "done")
+				return nil
+			}
+			if err := ss.Send(&healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}); err != nil {
+				return err
+			}
+			// dropped, the header is sent already.
+			tr.ResponseHeader().Set("x-late", "1")
+			tr.ResponseTrailer().Set("x-trailer", "done")
+			return ss.Send(&healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_NOT_SERVING})
+		},
+	}
+	client := newTestClient(t, srv,
+		[]grpc.ServerOption{
+			grpc.ChainUnaryInterceptor(dgrpc.UnaryServerInterceptor()),
+			grpc.ChainStreamInterceptor(dgrpc.StreamServerInterceptor()),
+		},
+	)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-token", "abc")
+
+	t.Run("unary", func(t *testing.T) {
+		var header, trailer metadata.MD
+		_, err := client.Check(ctx, &healthpb.HealthCheckRequest{}, grpc.Header(&header), grpc.Trailer(&trailer))
+		require.NoError(t, err)
+		require.Equal(t, []string{"abc"}, header.Get("x-echo"))
+		require.Equal(t, []string{"done"}, trailer.Get("x-trailer"))
+	})
+	t.Run("stream", func(t *testing.T) {
+		stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{})
+		require.NoError(t, err)
+		for range 2 {
+			_, err = stream.Recv()
+			require.NoError(t, err)
+		}
+		header, err := stream.Header()
+		require.NoError(t, err)
+		require.Equal(t, []string{"abc"}, header.Get("x-echo"))
+		require.Empty(t, header.Get("x-late"))
+		_, err = stream.Recv()
+		require.Error(t, err)
+		require.Equal(t, []string{"done"}, stream.Trailer().Get("x-trailer"))
+	})
+	t.Run("stream without message", func(t *testing.T) {
+		stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{Service: "empty"})
+		require.NoError(t, err)
+		_, err = stream.Recv()
+		require.Error(t, err)
+		header, err := stream.Header()
+		require.NoError(t, err)
+		require.Equal(t, []string{"abc"}, header.Get("x-echo"))
+		require.Equal(t, []string{"done"}, stream.Trailer().Get("x-trailer"))
+	})
+}
+
+func Test_WrappedStream(t *testing.T) {
+	type ctxKey struct{}
+	srv := &healthServer{
+		watch: func(req *healthpb.HealthCheckRequest, ss healthpb.Health_WatchServer) error {
+			require.Equal(t, "wrapped", ss.Context().Value(ctxKey{}))
+			return ss.Send(&healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING})
+		},
+	}
+	client := newTestClient(t, srv,
+		[]grpc.ServerOption{
+			grpc.ChainStreamInterceptor(func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+				return handler(srv, dgrpc.NewWrappedStream(context.WithValue(ss.Context(), ctxKey{}, "wrapped"), ss))
+			}),
+		},
+	)
+	stream, err := client.Watch(context.Background(), &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	reply, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, healthpb.HealthCheckResponse_SERVING, reply.Status)
+}
@@ -10,6 +10,8 @@ import (
 	"github.com/go-resty/resty/v2"
 	"github.com/things-go/encoding"
 	"golang.org/x/oauth2"
+
+	"github.com/things-go/dyn/transport"
 )
 
 var noRequestBodyMethods = map[string]struct{}{
@@ -96,7 +98,7 @@ func (c *Client) CallSetting(path string, cos ...CallOption) *CallSettings {
 	return cs
 }
 
-// Invoke the request
+// Invoke the request, the request id in context is forwarded, see `transport.FromRequestId`.
 // NOTE: Do not use this function. use Execute instead.
 func (c *Client) Invoke(ctx context.Context, method, path string, in, out any, settings *CallSettings) error {
 	if c.validate != nil {
@@ -125,6 +127,9 @@ func (c *Client) Invoke(ctx context.Context, method, path string, in, out any, s
 	}
 	r.SetHeader("Content-Type", settings.contentType)
 	r.SetHeader("Accept", settings.accept)
+	if id, ok := transport.FromRequestId(ctx); ok && settings.header.Get(transport.HeaderRequestId) == "" {
+		r.SetHeader(transport.HeaderRequestId, id)
+	}
 	for k, vs := range settings.header {
 		for _, v := range vs {
 			r.Header.Add(k, v)
@@ -3,6 +3,9 @@ package http
 import (
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type ErrorReply struct {
@@ -14,3 +17,29 @@ type ErrorReply struct {
 func (e *ErrorReply) Error() string {
 	return fmt.Sprintf("Invoke: Status Code: %d, Status Text: %s", e.Code, http.StatusText(e.Code))
 }
+
+// RetryAfter returns the delay indicated by the `Retry-After` header,
+// the header value can be delay-seconds or an HTTP-date.
+func (e *ErrorReply) RetryAfter() (time.Duration, bool) {
+	v := strings.TrimSpace(e.Header.Get("Retry-After"))
+	if v == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseInt(v, 10, 64); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(time.Until(t), 0), true
+	}
+	return 0, false
+}
+
+// Retryable reports whether the request can be retried,
+// it is true if the server returns the `Retry-After` header, or the status code
+// is 429(Too Many Requests) or 503(Service Unavailable).
+func (e *ErrorReply) Retryable() bool {
+	if _, ok := e.RetryAfter(); ok {
+		return true
+	}
+	return e.Code == http.StatusTooManyRequests || e.Code == http.StatusServiceUnavailable
+}
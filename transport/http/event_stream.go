@@ -0,0 +1,212 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/things-go/dyn/errorx"
+)
+
+// MIMEEventStream Content-Type MIME of the server-sent events.
+const MIMEEventStream = "text/event-stream"
+
+// EventCarrier is the Carrier which encodes the server-sent events of the server-streaming RPC,
+// the `EventStream` falls back to json if the Carrier does not implement it.
+type EventCarrier interface {
+	// EncodeEvent encode the message with the carrier encoding, it is the data of the `message` event.
+	EncodeEvent(*gin.Context, any) ([]byte, error)
+	// EncodeErrorEvent encode the error, same body as `Carrier.Error` renders, it is the data of the `error` event.
+	EncodeErrorEvent(*gin.Context, error) ([]byte, error)
+}
+
+var _ grpc.ServerStreamingServer[struct{}] = (*EventStream[struct{}])(nil)
+
+// ErrHeaderSent the response header is sent already, it can not be set any more.
+var ErrHeaderSent = errors.New("http: the response header is sent already")
+
+// EventStream is the server stream sender over the server-sent events,
+// each message is sent as a `message` event, the error as an `error` event.
+// it implements grpc.ServerStreamingServer, so the same implementation of the server-streaming method
+// serves both the gRPC and the server-sent events, the metadata is sent as the http header and trailer.
+type EventStream[T any] struct {
+	ctx   context.Context
+	c     *gin.Context
+	state *eventStreamState
+}
+
+type eventStreamState struct {
+	mu      sync.Mutex
+	started bool
+}
+
+// NewEventStream new server-sent events stream of the request.
+func NewEventStream[T any](c *gin.Context) *EventStream[T] {
+	return &EventStream[T]{
+		ctx:   c.Request.Context(),
+		c:     c,
+		state: &eventStreamState{},
+	}
+}
+
+// WithContext returns a shallow copy of the stream with the context, they share the same response.
+func (s *EventStream[T]) WithContext(ctx context.Context) *EventStream[T] {
+	return &EventStream[T]{
+		ctx:   ctx,
+		c:     s.c,
+		state: s.state,
+	}
+}
+
+// Context returns the context of the stream, it is canceled once the client disconnects.
+func (s *EventStream[T]) Context() context.Context { return s.ctx }
+
+// Send encode the message as a `message` event and flush it, the response header is sent on the first Send,
+// so the response header should be set before. it returns the context error once the client disconnects.
+func (s *EventStream[T]) Send(m *T) error {
+	if err := s.ctx.Err(); err != nil {
+		return err
+	}
+	data, err := s.encodeEvent(m)
+	if err != nil {
+		return err
+	}
+	s.state.mu.Lock()
+	defer s.state.mu.Unlock()
+	s.start()
+	return s.writeEvent("", data)
+}
+
+// Error render the error returned by the service, as an `error` event if any message is sent,
+// otherwise the error response rendered by `Carrier.Error`.
+// nothing is rendered once the client disconnects.
+func (s *EventStream[T]) Error(err error) {
+	if err == nil || s.c.Request.Context().Err() != nil {
+		return
+	}
+	s.state.mu.Lock()
+	defer s.state.mu.Unlock()
+	if !s.state.started {
+		FromCarrier(s.c.Request.Context()).Error(s.c, err)
+		return
+	}
+	data, err := s.encodeErrorEvent(err)
+	if err != nil {
+		return
+	}
+	_ = s.writeEvent("error", data)
+}
+
+// SetHeader sets the metadata as the response header, it returns ErrHeaderSent once any message is sent.
+func (s *EventStream[T]) SetHeader(md metadata.MD) error {
+	s.state.mu.Lock()
+	defer s.state.mu.Unlock()
+	return s.setHeader(md)
+}
+
+// SendHeader sets the metadata as the response header and sends the header,
+// it returns ErrHeaderSent once any message is sent.
+func (s *EventStream[T]) SendHeader(md metadata.MD) error {
+	s.state.mu.Lock()
+	defer s.state.mu.Unlock()
+	if err := s.setHeader(md); err != nil {
+		return err
+	}
+	s.start()
+	return nil
+}
+
+// SetTrailer sets the metadata as the response trailer, sent once the handler returns.
+func (s *EventStream[T]) SetTrailer(md metadata.MD) {
+	h := s.c.Writer.Header()
+	for k, vs := range md {
+		for _, v := range vs {
+			h.Add(http.TrailerPrefix+k, v)
+		}
+	}
+}
+
+// SendMsg sends the message, same as Send, the message must be *T.
+func (s *EventStream[T]) SendMsg(m any) error {
+	v, ok := m.(*T)
+	if !ok {
+		return fmt.Errorf("http: unexpected message type %T, want %T", m, v)
+	}
+	return s.Send(v)
+}
+
+// RecvMsg the request is bound already, there is no message from the client any more.
+func (s *EventStream[T]) RecvMsg(any) error {
+	return errors.New("http: the server-sent events stream does not receive messages")
+}
+
+func (s *EventStream[T]) setHeader(md metadata.MD) error {
+	if s.state.started {
+		return ErrHeaderSent
+	}
+	h := s.c.Writer.Header()
+	for k, vs := range md {
+		for _, v := range vs {
+			h.Add(k, v)
+		}
+	}
+	return nil
+}
+
+func (s *EventStream[T]) start() {
+	if s.state.started {
+		return
+	}
+	s.state.started = true
+	h := s.c.Writer.Header()
+	h.Set("Content-Type", MIMEEventStream)
+	h.Set("Cache-Control", "no-cache")
+	h.Set("Connection", "keep-alive")
+	// disable the proxy buffering, like nginx.
+	h.Set("X-Accel-Buffering", "no")
+	s.c.Status(http.StatusOK)
+	s.c.Writer.WriteHeaderNow()
+}
+
+// writeEvent write the event and flush it, each line of the data is a `data` field.
+func (s *EventStream[T]) writeEvent(event string, data []byte) error {
+	b := bytes.Buffer{}
+	if event != "" {
+		b.WriteString("event: ")
+		b.WriteString(event)
+		b.WriteByte('\n')
+	}
+	for _, line := range bytes.Split(bytes.TrimRight(data, "\r\n"), []byte("\n")) {
+		b.WriteString("data: ")
+		b.Write(bytes.TrimSuffix(line, []byte("\r")))
+		b.WriteByte('\n')
+	}
+	b.WriteByte('\n')
+	if _, err := s.c.Writer.Write(b.Bytes()); err != nil {
+		return err
+	}
+	s.c.Writer.Flush()
+	return nil
+}
+
+func (s *EventStream[T]) encodeEvent(v any) ([]byte, error) {
+	if ec, ok := s.c.Request.Context().Value(ctxCarrierKey{}).(EventCarrier); ok {
+		return ec.EncodeEvent(s.c, v)
+	}
+	return json.Marshal(v)
+}
+
+func (s *EventStream[T]) encodeErrorEvent(err error) ([]byte, error) {
+	if ec, ok := s.c.Request.Context().Value(ctxCarrierKey{}).(EventCarrier); ok {
+		return ec.EncodeErrorEvent(s.c, err)
+	}
+	return json.Marshal(errorx.Parse(err).WithContextRequestId(s.c.Request.Context()).Public())
+}
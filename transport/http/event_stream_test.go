@@ -0,0 +1,149 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/things-go/dyn/carry"
+	"github.com/things-go/dyn/errorx"
+	transportHttp "github.com/things-go/dyn/transport/http"
+)
+
+type eventReply struct {
+	Message string `json:"message"`
+}
+
+// indentCarrier encodes the event with indent, the data has multi lines.
+type indentCarrier struct {
+	*carry.Carry
+}
+
+func (indentCarrier) EncodeEvent(_ *gin.Context, v any) ([]byte, error) {
+	return json.MarshalIndent(v, "", "  ")
+}
+
+// plainCarrier does not implement the EventCarrier.
+type plainCarrier struct {
+	transportHttp.Carrier
+}
+
+func newEventStream(ctx context.Context, carrier transportHttp.Carrier) (*transportHttp.EventStream[eventReply], *httptest.ResponseRecorder) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/v1/hello", nil).
+		WithContext(transportHttp.WithValueCarrier(ctx, carrier))
+	return transportHttp.NewEventStream[eventReply](c), w
+}
+
+func Test_EventStream(t *testing.T) {
+	errNotFound := errorx.New(http.StatusNotFound, "not found")
+	for _, tt := range []struct {
+		name            string
+		carrier         transportHttp.Carrier
+		send            func(s *transportHttp.EventStream[eventReply])
+		wantCode        int
+		wantContentType string
+		wantBody        string
+	}{
+		{
+			name:    "messages",
+			carrier: carry.NewCarry(),
+			send: func(s *transportHttp.EventStream[eventReply]) {
+				require.NoError(t, s.Send(&eventReply{Message: "a"}))
+				require.NoError(t, s.SendMsg(&eventReply{Message: "b"}))
+			},
+			wantCode:        http.StatusOK,
+			wantContentType: transportHttp.MIMEEventStream,
+			wantBody:        "data: {\"message\":\"a\"}\n\ndata: {\"message\":\"b\"}\n\n",
+		},
+		{
+			name:    "multi-line data",
+			carrier: indentCarrier{carry.NewCarry()},
+			send: func(s *transportHttp.EventStream[eventReply]) {
+				require.NoError(t, s.Send(&eventReply{Message: "a"}))
+			},
+			wantCode:        http.StatusOK,
+			wantContentType: transportHttp.MIMEEventStream,
+			wantBody:        "data: {\ndata:   \"message\": \"a\"\ndata: }\n\n",
+		},
+		{
+			name:    "error event after started",
+			carrier: carry.NewCarry(carry.WithTransformError(carry.NewErrorTransformer())),
+			send: func(s *transportHttp.EventStream[eventReply]) {
+				require.NoError(t, s.Send(&eventReply{Message: "a"}))
+				s.Error(errNotFound)
+			},
+			wantCode:        http.StatusOK,
+			wantContentType: transportHttp.MIMEEventStream,
+			wantBody:        "data: {\"message\":\"a\"}\n\nevent: error\ndata: {\"code\":404,\"message\":\"not found\"}\n\n",
+		},
+		{
+			name:    "error event fallback to json",
+			carrier: plainCarrier{carry.NewCarry(carry.WithTransformError(carry.NewErrorTransformer()))},
+			send: func(s *transportHttp.EventStream[eventReply]) {
+				require.NoError(t, s.Send(&eventReply{Message: "a"}))
+				s.Error(errNotFound)
+			},
+			wantCode:        http.StatusOK,
+			wantContentType: transportHttp.MIMEEventStream,
+			wantBody:        "data: {\"message\":\"a\"}\n\nevent: error\ndata: {\"code\":404,\"message\":\"not found\"}\n\n",
+		},
+		{
+			name:    "error response before started",
+			carrier: carry.NewCarry(carry.WithTransformError(carry.NewErrorTransformer())),
+			send: func(s *transportHttp.EventStream[eventReply]) {
+				s.Error(errNotFound)
+			},
+			wantCode:        http.StatusNotFound,
+			wantContentType: "application/json; charset=utf-8",
+			wantBody:        `{"code":404,"message":"not found"}`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			s, w := newEventStream(context.Background(), tt.carrier)
+			tt.send(s)
+			require.Equal(t, tt.wantCode, w.Code)
+			require.Equal(t, tt.wantContentType, w.Header().Get("Content-Type"))
+			require.Equal(t, tt.wantBody, w.Body.String())
+		})
+	}
+}
+
+func Test_EventStream_Metadata(t *testing.T) {
+	s, w := newEventStream(context.Background(), carry.NewCarry())
+
+	require.NoError(t, s.SetHeader(metadata.Pairs("x-header", "1")))
+	require.NoError(t, s.SendHeader(metadata.Pairs("x-send-header", "2")))
+	require.ErrorIs(t, s.SetHeader(metadata.Pairs("x-late", "3")), transportHttp.ErrHeaderSent)
+	require.ErrorIs(t, s.SendHeader(nil), transportHttp.ErrHeaderSent)
+	s.SetTrailer(metadata.Pairs("x-trailer", "4"))
+	require.Error(t, s.SendMsg(&struct{}{}))
+	require.Error(t, s.RecvMsg(&eventReply{}))
+
+	resp := w.Result()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, transportHttp.MIMEEventStream, resp.Header.Get("Content-Type"))
+	require.Equal(t, "1", resp.Header.Get("X-Header"))
+	require.Equal(t, "2", resp.Header.Get("X-Send-Header"))
+	require.Empty(t, resp.Header.Get("X-Late"))
+	require.Equal(t, "4", resp.Trailer.Get("X-Trailer"))
+	require.Empty(t, w.Body.String())
+}
+
+func Test_EventStream_ClientGone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s, w := newEventStream(ctx, carry.NewCarry())
+	require.NoError(t, s.Send(&eventReply{Message: "a"}))
+	cancel()
+
+	require.ErrorIs(t, s.Send(&eventReply{Message: "b"}), context.Canceled)
+	s.Error(errorx.New(http.StatusNotFound, "not found"))
+	require.Equal(t, "data: {\"message\":\"a\"}\n\n", w.Body.String())
+}
@@ -2,19 +2,21 @@ package http
 
 import (
 	"github.com/gin-gonic/gin"
+
+	"github.com/things-go/dyn/transport/nethttp"
 )
 
 const ExclusivelyMetadataKey = "_dyn/transport/http/metadata"
 
-type Metadata struct {
-	Service string
-	Method  string
-}
+// Metadata the route metadata, same as `nethttp.Metadata`.
+type Metadata = nethttp.Metadata
 
-// MetadataInterceptor is used to store a `ExclusivelyMetadataKey`/Metadata pair exclusively for this `*gin.Context`.
+// MetadataInterceptor is used to store a `ExclusivelyMetadataKey`/Metadata pair exclusively for this `*gin.Context`,
+// it is also stored in the request context, see `nethttp.FromMetadata`.
 func MetadataInterceptor(md Metadata) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Set(ExclusivelyMetadataKey, md)
+		c.Request = c.Request.WithContext(nethttp.WithValueMetadata(c.Request.Context(), md))
 		c.Next()
 	}
 }
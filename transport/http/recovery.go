@@ -0,0 +1,78 @@
+package http
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/things-go/dyn/errorx"
+	"github.com/things-go/dyn/transport"
+)
+
+// RecoveryOption recovery interceptor option
+type RecoveryOption func(*recoveryOptions)
+
+type recoveryOptions struct {
+	logger *slog.Logger
+}
+
+// WithRecoveryLogger set the logger which logs the panic, default slog.Default().
+func WithRecoveryLogger(l *slog.Logger) RecoveryOption {
+	return func(o *recoveryOptions) {
+		o.logger = l
+	}
+}
+
+// RecoveryInterceptor recovery middleware, it recovers the panic into `errorx.NewInternalServer`
+// with the stack captured, logs it with the route Metadata and transport info,
+// then renders it with the Carrier in context, see `FromCarrier`.
+// the panic value is hidden from the client unless `deploy.IsTesting()`.
+// NOTE: `http.ErrAbortHandler` is re-panicked, so the connection is aborted as expected.
+func RecoveryInterceptor(opts ...RecoveryOption) gin.HandlerFunc {
+	o := &recoveryOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return func(c *gin.Context) {
+		defer func() {
+			r := recover()
+			if r == nil {
+				return
+			}
+			if err, ok := r.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+				panic(r)
+			}
+			err := errorx.FromPanic(r)
+			ctx := c.Request.Context()
+			attrs := []any{slog.Any("error", err)}
+			if md, ok := GetMetadata(c); ok {
+				attrs = append(attrs, slog.Group("route",
+					slog.String("service", md.Service),
+					slog.String("method", md.Method),
+				))
+			}
+			if tr, ok := transport.FromTransporter(ctx); ok {
+				attrs = append(attrs, slog.Group("transport",
+					slog.String("kind", tr.Kind().String()),
+					slog.String("path", tr.FullPath()),
+					slog.String("client_ip", tr.ClientIp()),
+				))
+			}
+			logger := o.logger
+			if logger == nil {
+				logger = slog.Default()
+			}
+			logger.ErrorContext(ctx, "panic recovered", attrs...)
+
+			if carrier, ok := ctx.Value(ctxCarrierKey{}).(Carrier); ok {
+				carrier.Error(c, err)
+				c.Abort()
+			} else {
+				c.AbortWithStatus(err.HTTPStatus())
+			}
+		}()
+		c.Next()
+	}
+}
@@ -0,0 +1,62 @@
+package http_test
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/things-go/dyn/carry"
+	transportHttp "github.com/things-go/dyn/transport/http"
+)
+
+func Test_RecoveryInterceptor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	buf := &bytes.Buffer{}
+	logger := slog.New(slog.NewJSONHandler(buf, nil))
+	newEngine := func(carrier transportHttp.Carrier) *gin.Engine {
+		g := gin.New()
+		if carrier != nil {
+			g.Use(transportHttp.CarrierInterceptor(carrier))
+		}
+		g.Use(transportHttp.TransportInterceptor(), transportHttp.RecoveryInterceptor(transportHttp.WithRecoveryLogger(logger)))
+		g.GET("/panic",
+			transportHttp.MetadataInterceptor(transportHttp.Metadata{Service: "Greeter", Method: "SayHello"}),
+			func(c *gin.Context) { panic("boom") },
+		)
+		g.GET("/abort", func(c *gin.Context) { panic(http.ErrAbortHandler) })
+		return g
+	}
+
+	t.Run("render with carrier", func(t *testing.T) {
+		buf.Reset()
+		w := httptest.NewRecorder()
+		carrier := carry.NewCarry(carry.WithTransformError(carry.NewErrorTransformer()))
+		newEngine(carrier).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/panic", nil))
+		require.Equal(t, http.StatusInternalServerError, w.Code)
+		// the panic value is hidden from the client.
+		require.Equal(t, `{"code":500,"message":"服务器错误"}`, w.Body.String())
+
+		require.Contains(t, buf.String(), `"msg":"panic recovered"`)
+		require.Contains(t, buf.String(), `"route":{"service":"Greeter","method":"SayHello"}`)
+		require.Contains(t, buf.String(), `"transport":{"kind":"http","path":"/panic"`)
+		require.Contains(t, buf.String(), "boom")
+	})
+	t.Run("status without carrier", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		newEngine(nil).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/panic", nil))
+		require.Equal(t, http.StatusInternalServerError, w.Code)
+		require.Empty(t, w.Body.String())
+	})
+	t.Run("re-panic the abort handler", func(t *testing.T) {
+		buf.Reset()
+		require.PanicsWithError(t, http.ErrAbortHandler.Error(), func() {
+			newEngine(carry.NewCarry()).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/abort", nil))
+		})
+		require.Empty(t, buf.String())
+	})
+}
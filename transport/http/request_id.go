@@ -0,0 +1,38 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/things-go/dyn/transport"
+	"github.com/things-go/dyn/transport/nethttp"
+)
+
+// RequestIdOption request id interceptor option
+type RequestIdOption func(*requestIdOptions)
+
+type requestIdOptions struct {
+	generator func() string
+}
+
+// WithRequestIdGenerator set the request id generator, default transport.NewRequestId.
+func WithRequestIdGenerator(f func() string) RequestIdOption {
+	return func(o *requestIdOptions) {
+		o.generator = f
+	}
+}
+
+// RequestIdInterceptor request id middleware, it reads the request id from the `X-Request-Id` header,
+// generates one if missing or invalid, then stores it in context, see `transport.FromRequestId`,
+// and echoes it in the response header.
+func RequestIdInterceptor(opts ...RequestIdOption) gin.HandlerFunc {
+	o := &requestIdOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return func(c *gin.Context) {
+		id := transport.RequestIdFromHeader(nethttp.Header(c.Request.Header), o.generator)
+		c.Request = c.Request.WithContext(transport.WithValueRequestId(c.Request.Context(), id))
+		c.Header(transport.HeaderRequestId, id)
+		c.Next()
+	}
+}
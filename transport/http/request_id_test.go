@@ -0,0 +1,65 @@
+package http_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/things-go/dyn/carry"
+	"github.com/things-go/dyn/errorx"
+	"github.com/things-go/dyn/transport"
+	transportHttp "github.com/things-go/dyn/transport/http"
+)
+
+func Test_RequestIdInterceptor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var gotId string
+	g := gin.New()
+	g.Use(
+		transportHttp.CarrierInterceptor(carry.NewCarry(carry.WithTransformError(carry.NewErrorTransformer()))),
+		transportHttp.RequestIdInterceptor(transportHttp.WithRequestIdGenerator(func() string { return "gen-1" })),
+	)
+	g.GET("/hello", func(c *gin.Context) {
+		gotId, _ = transport.FromRequestId(c.Request.Context())
+		c.Status(http.StatusOK)
+	})
+	g.GET("/error", func(c *gin.Context) {
+		transportHttp.FromCarrier(c.Request.Context()).Error(c, errorx.NewNotFound(errorx.WithMessage("not found")))
+	})
+
+	for _, tt := range []struct {
+		name   string
+		id     string
+		wantId string
+	}{
+		{"incoming", "req-1", "req-1"},
+		{"generate if missing", "", "gen-1"},
+		{"generate if invalid", "req 1", "gen-1"},
+		{"generate if too long", strings.Repeat("a", 129), "gen-1"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			gotId = ""
+			r := httptest.NewRequest(http.MethodGet, "/hello", nil)
+			if tt.id != "" {
+				r.Header.Set(transport.HeaderRequestId, tt.id)
+			}
+			w := httptest.NewRecorder()
+			g.ServeHTTP(w, r)
+			require.Equal(t, tt.wantId, gotId)
+			require.Equal(t, tt.wantId, w.Header().Get(transport.HeaderRequestId))
+		})
+	}
+	t.Run("error body carries the request id", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/error", nil)
+		r.Header.Set(transport.HeaderRequestId, "req-1")
+		w := httptest.NewRecorder()
+		g.ServeHTTP(w, r)
+		require.Equal(t, http.StatusNotFound, w.Code)
+		require.Equal(t, "req-1", w.Header().Get(transport.HeaderRequestId))
+		require.Equal(t, `{"code":404,"message":"not found","request_id":"req-1"}`, w.Body.String())
+	})
+}
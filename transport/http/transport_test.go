@@ -0,0 +1,56 @@
+package http_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/things-go/dyn/transport"
+	transportHttp "github.com/things-go/dyn/transport/http"
+)
+
+func Test_TransportInterceptor_ClientIp(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	newEngine := func(gotIp *string, opts ...transportHttp.TransportOption) *gin.Engine {
+		g := gin.New()
+		require.NoError(t, g.SetTrustedProxies([]string{"10.0.0.0/8"}))
+		g.Use(transportHttp.TransportInterceptor(opts...))
+		g.GET("/v1/hello/:id", func(c *gin.Context) {
+			tr, ok := transport.FromTransporter(c.Request.Context())
+			require.True(t, ok)
+			*gotIp = tr.ClientIp()
+		})
+		return g
+	}
+	newRequest := func(remoteAddr string) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, "/v1/hello/12", nil)
+		r.RemoteAddr = remoteAddr
+		r.Header.Set("X-Forwarded-For", "203.0.113.7, 10.0.0.2")
+		return r
+	}
+
+	for _, tt := range []struct {
+		name       string
+		opts       []transportHttp.TransportOption
+		remoteAddr string
+		wantIp     string
+	}{
+		{"gin trusted proxy", nil, "10.0.0.1:1234", "203.0.113.7"},
+		{"gin untrusted proxy", nil, "192.0.2.1:1234", "192.0.2.1"},
+		{
+			"resolver overrides gin",
+			[]transportHttp.TransportOption{transportHttp.WithClientIpResolver(transport.MustNewClientIpResolver())},
+			"10.0.0.1:1234",
+			"10.0.0.1",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotIp string
+			newEngine(&gotIp, tt.opts...).ServeHTTP(httptest.NewRecorder(), newRequest(tt.remoteAddr))
+			require.Equal(t, tt.wantIp, gotIp)
+		})
+	}
+}
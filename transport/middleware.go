@@ -0,0 +1,22 @@
+package transport
+
+import "context"
+
+// Handler defines the handler invoked by Middleware, it is the service method
+// with the request and reply erased, shared by HTTP and gRPC.
+type Handler func(ctx context.Context, req any) (any, error)
+
+// Middleware is the transport-agnostic middleware,
+// use `FromTransporter` to get the kind-specific data.
+type Middleware func(Handler) Handler
+
+// Chain returns a Middleware that specifies the chained handler,
+// the first middleware is the outermost one.
+func Chain(ms ...Middleware) Middleware {
+	return func(next Handler) Handler {
+		for i := len(ms) - 1; i >= 0; i-- {
+			next = ms[i](next)
+		}
+		return next
+	}
+}
@@ -0,0 +1,57 @@
+package transport_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/things-go/dyn/transport"
+)
+
+func Test_Chain(t *testing.T) {
+	var calls []string
+	record := func(name string) transport.Middleware {
+		return func(next transport.Handler) transport.Handler {
+			return func(ctx context.Context, req any) (any, error) {
+				calls = append(calls, name+" before")
+				reply, err := next(ctx, req)
+				calls = append(calls, name+" after")
+				return reply, err
+			}
+		}
+	}
+	handler := func(ctx context.Context, req any) (any, error) {
+		calls = append(calls, "handler")
+		return req, nil
+	}
+
+	t.Run("order", func(t *testing.T) {
+		calls = nil
+		reply, err := transport.Chain(record("m1"), record("m2"))(handler)(context.Background(), "hello")
+		require.NoError(t, err)
+		require.Equal(t, "hello", reply)
+		require.Equal(t, []string{"m1 before", "m2 before", "handler", "m2 after", "m1 after"}, calls)
+	})
+	t.Run("short-circuit", func(t *testing.T) {
+		errDenied := errors.New("denied")
+		deny := func(transport.Handler) transport.Handler {
+			return func(ctx context.Context, req any) (any, error) {
+				calls = append(calls, "deny")
+				return nil, errDenied
+			}
+		}
+		calls = nil
+		_, err := transport.Chain(record("m1"), deny, record("m2"))(handler)(context.Background(), "hello")
+		require.ErrorIs(t, err, errDenied)
+		require.Equal(t, []string{"m1 before", "deny", "m1 after"}, calls)
+	})
+	t.Run("empty", func(t *testing.T) {
+		calls = nil
+		reply, err := transport.Chain()(handler)(context.Background(), "hello")
+		require.NoError(t, err)
+		require.Equal(t, "hello", reply)
+		require.Equal(t, []string{"handler"}, calls)
+	})
+}
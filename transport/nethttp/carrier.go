@@ -0,0 +1,64 @@
+package nethttp
+
+import (
+	"context"
+	"net/http"
+)
+
+type ctxCarrierKey struct{}
+
+// Carrier is an HTTP Carrier, it is independent of any framework.
+type Carrier interface {
+	// Bind checks the Method and Content-Type to select codec.Marshaler automatically,
+	// Depending on the "Content-Type" header different bind are used.
+	Bind(*http.Request, any) error
+	// BindQuery binds the passed struct pointer using the query codec.Marshaler.
+	BindQuery(*http.Request, any) error
+	// BindUri binds the passed struct pointer using the uri codec.Marshaler, see `PathValues`.
+	BindUri(*http.Request, any) error
+	// ShouldBind checks the Method and Content-Type to select codec.Marshaler automatically then validate the request,
+	// Depending on the "Content-Type" header different bind are used.
+	ShouldBind(*http.Request, any) error
+	// ShouldBindQuery binds the passed struct pointer using the query codec.Marshaler then validate the request.
+	ShouldBindQuery(*http.Request, any) error
+	// ShouldBindUri binds the passed struct pointer using the uri codec.Marshaler then validate the request.
+	ShouldBindUri(*http.Request, any) error
+	// ShouldBindQueryUri binds the passed struct pointer using the query and uri codec.Marshaler then validate the request.
+	ShouldBindQueryUri(*http.Request, any) error
+	// ShouldBindBodyUri binds the passed struct pointer using the body and uri codec.Marshaler then validate the request.
+	ShouldBindBodyUri(*http.Request, any) error
+	// ShouldBindQueryBody binds the passed struct pointer using the query and body codec.Marshaler then validate the request.
+	ShouldBindQueryBody(*http.Request, any) error
+	// ShouldBindQueryBodyUri auto binds the passed struct pointer using query, body, uri codec.Marshaler if necessary.
+	ShouldBindQueryBodyUri(*http.Request, any) error
+	// Error encode error response.
+	Error(http.ResponseWriter, *http.Request, error)
+	// Render encode response.
+	Render(http.ResponseWriter, *http.Request, any)
+	// Validate the request.
+	Validate(context.Context, any) error
+}
+
+// WithValueCarrier returns the value associated with ctxCarrierKey is
+// Carrier.
+func WithValueCarrier(ctx context.Context, c Carrier) context.Context {
+	return context.WithValue(ctx, ctxCarrierKey{}, c)
+}
+
+// FromCarrier returns the Carrier value stored in ctx, if not exist cause panic.
+func FromCarrier(ctx context.Context) Carrier {
+	c, ok := ctx.Value(ctxCarrierKey{}).(Carrier)
+	if !ok {
+		panic("carrier: must be set Carrier into context but it is not!!!")
+	}
+	return c
+}
+
+// CarrierInterceptor carrier middleware.
+func CarrierInterceptor(carrier Carrier) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(WithValueCarrier(r.Context(), carrier)))
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package nethttp_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/things-go/dyn/carry"
+	"github.com/things-go/dyn/errorx"
+	"github.com/things-go/dyn/transport/nethttp"
+)
+
+func Test_CarrierInterceptor(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle("GET /v1/hello/{id}", nethttp.MetadataInterceptor(
+		nethttp.Metadata{Service: "Greeter", Method: "GetHello"},
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			md, ok := nethttp.FromMetadata(r.Context())
+			require.True(t, ok)
+			require.Equal(t, nethttp.Metadata{Service: "Greeter", Method: "GetHello"}, md)
+			nethttp.FromCarrier(r.Context()).Error(w, r, errorx.NewNotFound(errorx.WithMessage("not found")))
+		}),
+	))
+
+	w := httptest.NewRecorder()
+	carrier := carry.NewCarryStd(carry.WithTransformError(carry.NewErrorTransformer()))
+	nethttp.CarrierInterceptor(carrier)(mux).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/hello/12", nil))
+	require.Equal(t, http.StatusNotFound, w.Code)
+	require.Equal(t, `{"code":404,"message":"not found"}`, w.Body.String())
+
+	require.Panics(t, func() {
+		nethttp.FromCarrier(httptest.NewRequest(http.MethodGet, "/v1/hello/12", nil).Context())
+	})
+	_, ok := nethttp.FromMetadata(httptest.NewRequest(http.MethodGet, "/v1/hello/12", nil).Context())
+	require.False(t, ok)
+}
@@ -0,0 +1,39 @@
+package nethttp
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// PathValues returns the path values of the wildcards in the matched `http.ServeMux` pattern,
+// like "GET /v1/hello/{id}" or "/files/{path...}", the "{$}" is not a wildcard.
+// it is empty if the request is not routed by the `http.ServeMux`.
+func PathValues(r *http.Request) url.Values {
+	names := patternWildcards(r.Pattern)
+	vars := make(url.Values, len(names))
+	for _, name := range names {
+		vars.Add(name, r.PathValue(name))
+	}
+	return vars
+}
+
+// patternWildcards returns the wildcard names of the `http.ServeMux` pattern.
+func patternWildcards(pattern string) []string {
+	var names []string
+	for {
+		i := strings.IndexByte(pattern, '{')
+		if i < 0 {
+			return names
+		}
+		j := strings.IndexByte(pattern[i:], '}')
+		if j < 0 {
+			return names
+		}
+		name := strings.TrimSuffix(pattern[i+1:i+j], "...")
+		if name != "" && name != "$" {
+			names = append(names, name)
+		}
+		pattern = pattern[i+j+1:]
+	}
+}
@@ -0,0 +1,44 @@
+package nethttp_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/things-go/dyn/transport"
+	"github.com/things-go/dyn/transport/nethttp"
+)
+
+func Test_PathValues(t *testing.T) {
+	for _, tt := range []struct {
+		pattern   string
+		target    string
+		wantRoute string
+		want      url.Values
+	}{
+		{"GET /v1/hello/{id}", "/v1/hello/12", "/v1/hello/{id}", url.Values{"id": {"12"}}},
+		{"/v1/{name}/sub/{sub...}", "/v1/a/sub/b/c", "/v1/{name}/sub/{sub...}", url.Values{"name": {"a"}, "sub": {"b/c"}}},
+		{"GET example.com/v1/{$}", "http://example.com/v1/", "/v1/{$}", url.Values{}},
+	} {
+		t.Run(tt.pattern, func(t *testing.T) {
+			var got url.Values
+			var gotRoute string
+			mux := http.NewServeMux()
+			mux.HandleFunc(tt.pattern, func(w http.ResponseWriter, r *http.Request) {
+				got = nethttp.PathValues(r)
+				tr, ok := transport.FromTransporter(r.Context())
+				require.True(t, ok)
+				gotRoute = tr.(nethttp.Transporter).Route()
+			})
+			nethttp.TransportInterceptor()(mux).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, tt.target, nil))
+			require.Equal(t, tt.want, got)
+			require.Equal(t, tt.wantRoute, gotRoute)
+		})
+	}
+
+	// not routed by the http.ServeMux.
+	require.Empty(t, nethttp.PathValues(httptest.NewRequest(http.MethodGet, "/v1/hello/12", nil)))
+}
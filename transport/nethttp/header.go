@@ -0,0 +1,138 @@
+package nethttp
+
+import (
+	"net/http"
+	"net/textproto"
+	"slices"
+	"strings"
+
+	"github.com/things-go/dyn/transport"
+)
+
+var _ transport.Header = (Header)(nil)
+var _ transport.Header = (trailer)(nil)
+
+// Header is the http header, it implements transport.Header.
+type Header http.Header
+
+// Len returns the number of items in header.
+func (h Header) Len() int { return len(h) }
+
+// Get returns the value associated with the passed key.
+func (h Header) Get(key string) string { return http.Header(h).Get(key) }
+
+// Add adds the key, value pair to the header.
+func (h Header) Add(key, value string) { http.Header(h).Add(key, value) }
+
+// Set stores the key-value pair.
+func (h Header) Set(key string, value string) { http.Header(h).Set(key, value) }
+
+// Append adds the values to key k, not overwriting what was already stored at
+// that key.
+//
+// k is converted to lowercase before storing in header.
+func (h Header) Append(key string, vals ...string) {
+	if len(vals) == 0 {
+		return
+	}
+	key = textproto.CanonicalMIMEHeaderKey(key)
+	h[key] = append(h[key], vals...)
+}
+
+// Delete removes the values for a given key k which is converted to lowercase
+// before removing it from header.
+func (h Header) Delete(key string) { textproto.MIMEHeader(h).Del(key) }
+
+// Keys lists the keys stored in this carrier.
+func (h Header) Keys() []string {
+	keys := make([]string, 0, len(h))
+	for k := range http.Header(h) {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// Clone returns a copy of h or nil if h is nil.
+func (h Header) Clone() transport.Header { return transport.Header(Header(http.Header(h).Clone())) }
+
+// trailer is the http response trailer, it is stored in the response header
+// with the `http.TrailerPrefix`, so it is sent after the body.
+// the key is also declared in the `Trailer` header, so the trailer set before
+// the body is written forces the chunked encoding of HTTP/1.1 that trailers require.
+type trailer http.Header
+
+func trailerKey(key string) string {
+	return http.TrailerPrefix + textproto.CanonicalMIMEHeaderKey(key)
+}
+
+// declare declares the key in the `Trailer` header, if not declared yet.
+func (t trailer) declare(key string) {
+	key = textproto.CanonicalMIMEHeaderKey(key)
+	for _, v := range t["Trailer"] {
+		for _, k := range strings.Split(v, ",") {
+			if textproto.CanonicalMIMEHeaderKey(strings.TrimSpace(k)) == key {
+				return
+			}
+		}
+	}
+	t["Trailer"] = append(t["Trailer"], key)
+}
+
+// Len returns the number of items in trailer.
+func (t trailer) Len() int { return len(t.Keys()) }
+
+// Get returns the value associated with the passed key.
+func (t trailer) Get(key string) string {
+	if vals := t[trailerKey(key)]; len(vals) > 0 {
+		return vals[0]
+	}
+	return ""
+}
+
+// Add adds the key, value pair to the trailer.
+func (t trailer) Add(key, value string) { t.Append(key, value) }
+
+// Set stores the key-value pair.
+func (t trailer) Set(key string, value string) {
+	t.declare(key)
+	t[trailerKey(key)] = []string{value}
+}
+
+// Append adds the values to key k, not overwriting what was already stored at
+// that key.
+func (t trailer) Append(key string, vals ...string) {
+	if len(vals) == 0 {
+		return
+	}
+	t.declare(key)
+	k := trailerKey(key)
+	t[k] = append(t[k], vals...)
+}
+
+// Delete removes the values for a given key k.
+func (t trailer) Delete(key string) { delete(t, trailerKey(key)) }
+
+// Keys lists the keys stored in this trailer, without the `http.TrailerPrefix`.
+func (t trailer) Keys() []string {
+	keys := make([]string, 0, len(t))
+	for k := range t {
+		if key, ok := strings.CutPrefix(k, http.TrailerPrefix); ok {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// Clone returns a copy of t or nil if t is nil, only the trailer keys are copied.
+func (t trailer) Clone() transport.Header {
+	if t == nil {
+		return nil
+	}
+	ct := make(trailer)
+	for k, vs := range t {
+		if strings.HasPrefix(k, http.TrailerPrefix) {
+			ct[k] = slices.Clone(vs)
+		}
+	}
+	return ct
+}
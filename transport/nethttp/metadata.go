@@ -0,0 +1,32 @@
+package nethttp
+
+import (
+	"context"
+	"net/http"
+)
+
+// Metadata the route metadata.
+type Metadata struct {
+	Service string
+	Method  string
+}
+
+type ctxMetadataKey struct{}
+
+// WithValueMetadata returns a new Context that carries the route Metadata.
+func WithValueMetadata(ctx context.Context, md Metadata) context.Context {
+	return context.WithValue(ctx, ctxMetadataKey{}, md)
+}
+
+// FromMetadata returns the route Metadata value stored in ctx, if any.
+func FromMetadata(ctx context.Context) (md Metadata, ok bool) {
+	md, ok = ctx.Value(ctxMetadataKey{}).(Metadata)
+	return
+}
+
+// MetadataInterceptor is used to store the route Metadata in the request context for the handler.
+func MetadataInterceptor(md Metadata, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(WithValueMetadata(r.Context(), md)))
+	})
+}
@@ -0,0 +1,117 @@
+// Package nethttp is the framework-neutral http transport based on `http.ResponseWriter` and `*http.Request`,
+// it works with the standard library `http.ServeMux`(Go 1.22+ patterns) and any router
+// compatible with `http.Handler`, like chi or echo.
+package nethttp
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/things-go/dyn/transport"
+)
+
+var _ Transporter = (*Transport)(nil)
+
+// Transporter is http Transporter
+type Transporter interface {
+	transport.Transporter
+	Method() string
+	Route() string
+}
+
+// Transport is an HTTP transport.
+type Transport struct {
+	fullPath       string
+	method         string
+	clientIp       string
+	requestHeader  Header
+	responseHeader Header
+	request        *http.Request
+}
+
+// NewTransport new http transport of the request,
+// the response header is the header of the http.ResponseWriter.
+func NewTransport(w http.ResponseWriter, r *http.Request, clientIp string) *Transport {
+	return &Transport{
+		r.URL.Path,
+		r.Method,
+		clientIp,
+		Header(r.Header),
+		Header(w.Header()),
+		r,
+	}
+}
+
+// Kind returns the transport kind.
+func (tr *Transport) Kind() transport.Kind { return transport.HTTP }
+
+// FullPath Service full method or path
+func (tr *Transport) FullPath() string { return tr.fullPath }
+
+// ClientIp client ip
+func (tr *Transport) ClientIp() string { return tr.clientIp }
+
+// RequestHeader return transport request header
+// http: http.Header
+// grpc: metadata.MD
+func (tr *Transport) RequestHeader() transport.Header { return tr.requestHeader }
+
+// ResponseHeader return transport response header
+// http: http.Header
+// grpc: metadata.MD
+func (tr *Transport) ResponseHeader() transport.Header { return tr.responseHeader }
+
+// ResponseTrailer return transport response trailer
+// http: http.Header, stored in the response header with the `http.TrailerPrefix`
+// grpc: metadata.MD
+func (tr *Transport) ResponseTrailer() transport.Header { return trailer(tr.responseHeader) }
+
+// Method Service http method
+func (tr *Transport) Method() string { return tr.method }
+
+// Route Service full route, the path of the matched `http.ServeMux` pattern, like "/v1/hello/{id}",
+// it is empty until the request is routed by the `http.ServeMux`.
+func (tr *Transport) Route() string { return patternPath(tr.request.Pattern) }
+
+// patternPath returns the path of the `http.ServeMux` pattern "[METHOD ][HOST]/[PATH]".
+func patternPath(pattern string) string {
+	if i := strings.IndexByte(pattern, '/'); i >= 0 {
+		return pattern[i:]
+	}
+	return ""
+}
+
+// TransportOption transport interceptor option
+type TransportOption func(*transportOptions)
+
+type transportOptions struct {
+	clientIpResolver *transport.ClientIpResolver
+}
+
+// WithClientIpResolver set the client ip resolver, default trusts no proxy,
+// the peer address is always the client ip.
+func WithClientIpResolver(r *transport.ClientIpResolver) TransportOption {
+	return func(o *transportOptions) {
+		o.clientIpResolver = r
+	}
+}
+
+// TransportInterceptor transport middleware,
+// it places the Transporter in context, see `transport.FromTransporter`.
+// NOTE: `http.ServeMux` sets the matched pattern on the request it routes,
+// wrap the mux directly, so the `Transporter.Route()` reflects it.
+func TransportInterceptor(opts ...TransportOption) func(http.Handler) http.Handler {
+	o := &transportOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tr := NewTransport(w, r, o.clientIpResolver.Resolve(r.RemoteAddr, r.Header.Values))
+			r = r.WithContext(transport.WithValueTransporter(r.Context(), tr))
+			// the mux routes this request, so the matched pattern is visible to the Transporter.
+			tr.request = r
+			next.ServeHTTP(w, r)
+		})
+	}
+}
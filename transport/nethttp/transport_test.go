@@ -0,0 +1,72 @@
+package nethttp_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/things-go/dyn/transport"
+	"github.com/things-go/dyn/transport/nethttp"
+)
+
+func Test_TransportInterceptor(t *testing.T) {
+	var tr nethttp.Transporter
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/hello/{id}", func(w http.ResponseWriter, r *http.Request) {
+		v, ok := transport.FromTransporter(r.Context())
+		require.True(t, ok)
+		tr = v.(nethttp.Transporter)
+		tr.ResponseHeader().Set("X-Echo", tr.RequestHeader().Get("X-Token"))
+		tr.ResponseTrailer().Set("X-Trailer", "done")
+		_, _ = w.Write([]byte("hello"))
+	})
+
+	t.Run("transporter", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/v1/hello/12", nil)
+		r.RemoteAddr = "192.0.2.1:1234"
+		r.Header.Set("X-Token", "abc")
+		w := httptest.NewRecorder()
+		nethttp.TransportInterceptor()(mux).ServeHTTP(w, r)
+
+		require.Equal(t, transport.HTTP, tr.Kind())
+		require.Equal(t, "/v1/hello/12", tr.FullPath())
+		require.Equal(t, "/v1/hello/{id}", tr.Route())
+		require.Equal(t, http.MethodPost, tr.Method())
+		require.Equal(t, "192.0.2.1", tr.ClientIp())
+		require.Equal(t, "abc", w.Header().Get("X-Echo"))
+		require.Equal(t, "done", tr.ResponseTrailer().Get("X-Trailer"))
+		require.Equal(t, []string{"X-Trailer"}, tr.ResponseTrailer().Keys())
+	})
+	t.Run("client ip behind trusted proxies", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/v1/hello/12", nil)
+		r.RemoteAddr = "10.0.0.1:1234"
+		r.Header.Set("X-Forwarded-For", "203.0.113.7, 10.0.0.2")
+		resolver := transport.MustNewClientIpResolver("10.0.0.0/8")
+		nethttp.TransportInterceptor(nethttp.WithClientIpResolver(resolver))(mux).ServeHTTP(httptest.NewRecorder(), r)
+		require.Equal(t, "203.0.113.7", tr.ClientIp())
+	})
+	t.Run("route is empty if not routed by the mux", func(t *testing.T) {
+		var route string
+		h := nethttp.TransportInterceptor()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			v, _ := transport.FromTransporter(r.Context())
+			route = v.(nethttp.Transporter).Route()
+		}))
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/hello/12", nil))
+		require.Empty(t, route)
+	})
+	t.Run("trailer is sent after the body", func(t *testing.T) {
+		ts := httptest.NewServer(nethttp.TransportInterceptor()(mux))
+		t.Cleanup(ts.Close)
+
+		resp, err := http.Post(ts.URL+"/v1/hello/12", "text/plain", nil)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.Equal(t, "hello", string(body))
+		require.Equal(t, "done", resp.Trailer.Get("X-Trailer"))
+	})
+}
@@ -0,0 +1,61 @@
+package transport
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// HeaderRequestId the header key of the request id,
+// the gRPC metadata key is the lower case `x-request-id`.
+const HeaderRequestId = "X-Request-Id"
+
+// maxRequestIdLength the max length of the incoming request id, longer one is discarded.
+const maxRequestIdLength = 128
+
+// NewRequestId generate a new random request id, 32 hex characters.
+func NewRequestId() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// ValidRequestId reports whether the incoming request id is acceptable,
+// it must be non-empty, at most 128 characters, and only contains printable ASCII characters,
+// so it is safe to log and echo.
+func ValidRequestId(id string) bool {
+	if id == "" || len(id) > maxRequestIdLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// RequestIdFromHeader returns the request id in the header if it is valid,
+// otherwise generate a new one with gen, if gen is nil, `NewRequestId` is used.
+func RequestIdFromHeader(h Header, gen func() string) string {
+	if id := h.Get(HeaderRequestId); ValidRequestId(id) {
+		return id
+	}
+	if gen == nil {
+		gen = NewRequestId
+	}
+	return gen()
+}
+
+type ctxRequestIdKey struct{}
+
+// WithValueRequestId returns a new Context that carries the request id.
+func WithValueRequestId(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, ctxRequestIdKey{}, id)
+}
+
+// FromRequestId returns the request id stored in ctx, if any.
+func FromRequestId(ctx context.Context) (id string, ok bool) {
+	id, ok = ctx.Value(ctxRequestIdKey{}).(string)
+	return
+}
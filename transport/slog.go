@@ -0,0 +1,93 @@
+package transport
+
+import (
+	"context"
+	"log/slog"
+)
+
+var _ slog.Handler = (*LogHandler)(nil)
+
+// LogHandler is a slog.Handler wrapper, it enriches the record with the transport info,
+// kind, full path and client ip, from the `Transporter` in context, if any,
+// and the request id in context, if any.
+// The transport info and the request id are always at the top level, even after WithGroup.
+type LogHandler struct {
+	slog.Handler
+	// base the handler before the first group.
+	base slog.Handler
+	// goas the groups and attrs applied to base after the first group.
+	goas []groupOrAttrs
+}
+
+// groupOrAttrs is a group or the attrs, only one of them is set.
+type groupOrAttrs struct {
+	group string
+	attrs []slog.Attr
+}
+
+// NewLogHandler new LogHandler wrapping the handler.
+func NewLogHandler(h slog.Handler) *LogHandler {
+	return &LogHandler{Handler: h, base: h}
+}
+
+// Handle implement slog.Handler
+func (h *LogHandler) Handle(ctx context.Context, r slog.Record) error {
+	var attrs []slog.Attr
+
+	if tr, ok := FromTransporter(ctx); ok {
+		attrs = append(attrs, slog.Group("transport",
+			slog.String("kind", tr.Kind().String()),
+			slog.String("path", tr.FullPath()),
+			slog.String("client_ip", tr.ClientIp()),
+		))
+	}
+	if id, ok := FromRequestId(ctx); ok {
+		attrs = append(attrs, slog.String("request_id", id))
+	}
+	if len(attrs) == 0 {
+		return h.Handler.Handle(ctx, r)
+	}
+	if len(h.goas) == 0 {
+		r = r.Clone()
+		r.AddAttrs(attrs...)
+		return h.Handler.Handle(ctx, r)
+	}
+	// the record attrs are qualified by the groups, so add the attrs to the base
+	// before the first group, then replay the groups and attrs.
+	handler := h.base.WithAttrs(attrs)
+	for _, goa := range h.goas {
+		if goa.group != "" {
+			handler = handler.WithGroup(goa.group)
+		} else {
+			handler = handler.WithAttrs(goa.attrs)
+		}
+	}
+	return handler.Handle(ctx, r)
+}
+
+// WithAttrs implement slog.Handler
+func (h *LogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	if len(h.goas) == 0 {
+		handler := h.Handler.WithAttrs(attrs)
+		return &LogHandler{Handler: handler, base: handler}
+	}
+	return h.withGroupOrAttrs(h.Handler.WithAttrs(attrs), groupOrAttrs{attrs: attrs})
+}
+
+// WithGroup implement slog.Handler
+func (h *LogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return h.withGroupOrAttrs(h.Handler.WithGroup(name), groupOrAttrs{group: name})
+}
+
+func (h *LogHandler) withGroupOrAttrs(handler slog.Handler, goa groupOrAttrs) *LogHandler {
+	goas := make([]groupOrAttrs, 0, len(h.goas)+1)
+	goas = append(goas, h.goas...)
+	goas = append(goas, goa)
+	return &LogHandler{Handler: handler, base: h.base, goas: goas}
+}
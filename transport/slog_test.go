@@ -0,0 +1,92 @@
+package transport_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/things-go/dyn/transport"
+)
+
+type testTransporter struct {
+	transport.Transporter
+}
+
+func (testTransporter) Kind() transport.Kind { return transport.HTTP }
+func (testTransporter) FullPath() string     { return "/v1/hello" }
+func (testTransporter) ClientIp() string     { return "192.0.2.1" }
+
+func Test_LogHandler(t *testing.T) {
+	ctx := transport.WithValueTransporter(context.Background(), testTransporter{})
+	ctx = transport.WithValueRequestId(ctx, "req-1")
+	wantTransport := map[string]any{
+		"kind":      "http",
+		"path":      "/v1/hello",
+		"client_ip": "192.0.2.1",
+	}
+
+	tests := []struct {
+		name  string
+		with  func(l *slog.Logger) *slog.Logger
+		check func(t *testing.T, m map[string]any)
+	}{
+		{
+			name: "plain",
+			with: func(l *slog.Logger) *slog.Logger { return l },
+			check: func(t *testing.T, m map[string]any) {
+				require.Equal(t, 1.0, m["a"])
+			},
+		},
+		{
+			name: "with attrs",
+			with: func(l *slog.Logger) *slog.Logger { return l.With("b", 2) },
+			check: func(t *testing.T, m map[string]any) {
+				require.Equal(t, 2.0, m["b"])
+				require.Equal(t, 1.0, m["a"])
+			},
+		},
+		{
+			name: "with group",
+			with: func(l *slog.Logger) *slog.Logger {
+				return l.With("b", 2).WithGroup("g").With("c", 3).WithGroup("h")
+			},
+			check: func(t *testing.T, m map[string]any) {
+				require.Equal(t, 2.0, m["b"])
+				require.Equal(t, map[string]any{
+					"c": 3.0,
+					"h": map[string]any{"a": 1.0},
+				}, m["g"])
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			l := slog.New(transport.NewLogHandler(slog.NewJSONHandler(buf, nil)))
+			tt.with(l).InfoContext(ctx, "hello", "a", 1)
+
+			var m map[string]any
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &m))
+			require.Equal(t, "hello", m["msg"])
+			require.Equal(t, "req-1", m["request_id"])
+			require.Equal(t, wantTransport, m["transport"])
+			tt.check(t, m)
+		})
+	}
+
+	t.Run("no transport", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		l := slog.New(transport.NewLogHandler(slog.NewJSONHandler(buf, nil)))
+		l.WithGroup("g").InfoContext(context.Background(), "hello", "a", 1)
+
+		var m map[string]any
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &m))
+		require.NotContains(t, m, "request_id")
+		require.NotContains(t, m, "transport")
+		require.Equal(t, map[string]any{"a": 1.0}, m["g"])
+	})
+}
@@ -21,6 +21,10 @@ type Transporter interface {
 	// http: http.Header
 	// grpc: metadata.MD
 	ResponseHeader() Header
+	// ResponseTrailer return transport response trailer
+	// http: http.Header, stored in the response header with the `http.TrailerPrefix`
+	// grpc: metadata.MD
+	ResponseTrailer() Header
 }
 
 // Header is the storage medium used by a Header.
@@ -76,3 +80,18 @@ func MustFromTransporter(ctx context.Context) Transporter {
 	}
 	return p
 }
+
+type ctxClientTransportKey struct{}
+
+// WithValueClientTransporter returns a new Context that carries the client side value.
+// the client Transporter describes the outgoing call, it is stored separately,
+// so it does not shadow the server Transporter of the incoming request.
+func WithValueClientTransporter(ctx context.Context, p Transporter) context.Context {
+	return context.WithValue(ctx, ctxClientTransportKey{}, p)
+}
+
+// FromClientTransporter returns the client side Transporter value stored in ctx, if any.
+func FromClientTransporter(ctx context.Context) (p Transporter, ok bool) {
+	p, ok = ctx.Value(ctxClientTransportKey{}).(Transporter)
+	return
+}